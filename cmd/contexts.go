@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"kdiff/internal/helpers"
+	"kdiff/internal/kube"
+	"kdiff/internal/report"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/strings/slices"
+)
+
+func contextsCmd() *cobra.Command {
+	var (
+		output  string
+		timeout time.Duration
+	)
+
+	command := cobra.Command{
+		Use:   "contexts",
+		Short: "Print reachability & server versions of all contexts",
+		Long:  "Check every context in the kubeconfig file and print its reachability, server version & latency",
+		Example: "  kdiff contexts\n" +
+			"  kdiff contexts --context prod\n" +
+			"  kdiff contexts --timeout 5s -o json",
+		Run: func(cmd *cobra.Command, args []string) {
+			runContexts(output, timeout)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "table", fmt.Sprintf("Output format (%s)", strings.Join(report.ContextFormats, ", ")))
+	command.Flags().DurationVarP(&timeout, "timeout", "t", kube.DefaultContextTimeout, "Timeout for each context to respond")
+
+	return &command
+}
+
+func runContexts(output string, timeout time.Duration) {
+	if !slices.Contains(report.ContextFormats, output) {
+		log.Fatalf("Unknown output format %q, must be one of %v", output, report.ContextFormats)
+	}
+
+	kubeFlags := getKubeConfigFlags()
+	if *kubeFlags.Namespace != "" {
+		log.Fatalf("--namespace is not supported by the contexts command")
+	}
+
+	var kconfig kube.KubeConfig
+	kconfig.ParseConfig(kubeFlags)
+	contexts := kconfig.GetContextInfo(timeout)
+
+	// Limit the output to the context set with the kubectl --context flag.
+	if *kubeFlags.Context != "" {
+		var selected []*kube.KubeContext
+		for _, ctx := range contexts {
+			if ctx.Name == *kubeFlags.Context {
+				selected = append(selected, ctx)
+			}
+		}
+		if len(selected) == 0 {
+			log.Fatalf("Context %q not found in kubeconfig", *kubeFlags.Context)
+		}
+		contexts = selected
+	}
+	helpers.HandleError(report.WriteContexts(os.Stdout, output, contexts))
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"kdiff/internal/kube"
+	"kdiff/internal/report"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Exit codes used by the diff command when a failure condition is enabled. They differ
+	// from the exit code of errors (1) and of a Go panic (2), so that a failed comparison
+	// can't be mistaken for drift.
+	exitCodeDrift   = 10
+	exitCodeMissing = 11
+)
+
+type diffFailOptions struct {
+	onDrift   bool
+	onMissing bool
+}
+
+type diffCheckOptions struct {
+	pods       bool
+	rollouts   bool
+	dimensions []string
+}
+
+func diffCmd() *cobra.Command {
+	var (
+		contexts, namespaces, kinds []string
+		output                      string
+		opts                        report.Options
+		failOpts                    diffFailOptions
+		checkOpts                   diffCheckOptions
+	)
+
+	command := cobra.Command{
+		Use:   "diff",
+		Short: "Print the comparison without the terminal UI",
+		Long:  "Compare container images across contexts and print the result to stdout",
+		Example: "  kdiff diff --context staging,prod --namespace default --kind Deployment\n" +
+			"  kdiff diff -c staging,prod -d\n" +
+			"  kdiff diff -c staging,prod -k Deployment,ConfigMap,Secret\n" +
+			"  kdiff diff -c staging,prod -o json\n" +
+			"  kdiff diff -c staging,prod --compare env\n" +
+			"  kdiff diff -c staging,prod --compare replicas --show-ready\n" +
+			"  kdiff diff -c staging,prod --fail-on-drift --fail-on-missing",
+		Run: func(cmd *cobra.Command, args []string) {
+			runDiff(contexts, namespaces, kinds, output, opts, failOpts, checkOpts)
+		},
+	}
+	command.Flags().StringSliceVarP(&contexts, "context", "c", nil, "Contexts to compare (comma separated)")
+	command.Flags().StringSliceVarP(&namespaces, "namespace", "n", nil, "Namespaces to compare (default all namespaces)")
+	command.Flags().StringSliceVarP(&kinds, "kind", "k", nil, "Resource types to compare (default all workload resource types)")
+	command.Flags().StringVarP(&output, "output", "o", "table", fmt.Sprintf("Output format (%s)", strings.Join(report.Formats, ", ")))
+	command.Flags().BoolVarP(&opts.DifferencesOnly, "differences-only", "d", false, "Only print resources with differences")
+	command.Flags().BoolVar(&opts.ShowImageHash, "show-hash", false, "Include image hashes")
+	command.Flags().BoolVar(&opts.ShowReadyReplicas, "show-ready", false, "Include ready replica counts of workloads (with --compare replicas)")
+	command.Flags().BoolVarP(&checkOpts.pods, "check-pods", "p", false, "Check if running pods match the declared images")
+	command.Flags().StringSliceVar(&checkOpts.dimensions, "compare", nil, fmt.Sprintf("Dimensions to compare in addition to images (%s)", strings.Join(kube.Dimensions, ", ")))
+	command.Flags().BoolVar(&checkOpts.rollouts, "rollouts", false, "Show every active image of deployments which are rolling out with its replica counts")
+	command.Flags().BoolVar(&failOpts.onDrift, "fail-on-drift", false, fmt.Sprintf("Exit with code %d if any container image, compared dimension or field differs across contexts (or running pods differ with --check-pods)", exitCodeDrift))
+	command.Flags().BoolVar(&failOpts.onMissing, "fail-on-missing", false, fmt.Sprintf("Exit with code %d if any resource doesn't exist in all contexts", exitCodeMissing))
+	command.MarkFlagRequired("context")
+
+	return &command
+}
+
+func runDiff(contexts, namespaces, kinds []string, output string, opts report.Options, failOpts diffFailOptions, checkOpts diffCheckOptions) {
+	if !report.IsFormat(output) {
+		log.Fatalf("Unknown output format %q, must be one of %v", output, report.Formats)
+	}
+	// Custom resources are registered after flags are defined, so they can't be part of the flag default.
+	if len(kinds) == 0 {
+		kinds = kube.GetWorkloadResourceTypes()
+	}
+	for _, kind := range kinds {
+		if !kube.IsResourceType(kind) {
+			log.Fatalf("Unknown kind %q, must be one of %v", kind, kube.ResourceTypes)
+		}
+	}
+	for _, dimension := range checkOpts.dimensions {
+		if !kube.IsDimension(dimension) {
+			log.Fatalf("Unknown dimension %q, must be one of %v", dimension, kube.Dimensions)
+		}
+	}
+	// CSV & TSV are an image inventory, which has no columns for compared dimensions.
+	if len(checkOpts.dimensions) > 0 && (output == "csv" || output == "tsv") {
+		log.Fatalf("--compare is not supported with output format %q", output)
+	}
+	// An empty namespace fetches resources from all namespaces.
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	if err := initKubeClients(contexts); err != nil {
+		log.Fatal(err)
+	}
+
+	comparison, err := kube.CompareResources(contexts, kinds, namespaces)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if checkOpts.pods {
+		if err := comparison.CheckRunningImages(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if checkOpts.rollouts {
+		if err := comparison.CheckRollouts(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	comparison.CompareDimensions(checkOpts.dimensions)
+	if err := report.Write(os.Stdout, output, comparison, opts); err != nil {
+		log.Fatal(err)
+	}
+
+	// Drift takes precedence over missing resources if both are enabled.
+	if failOpts.onDrift && (comparison.HasAnyMismatch() || comparison.HasAnyRunningMismatch()) {
+		os.Exit(exitCodeDrift)
+	}
+	if failOpts.onMissing && comparison.HasAnyMissing() {
+		os.Exit(exitCodeMissing)
+	}
+}
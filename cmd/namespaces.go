@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"kdiff/internal/helpers"
+	"kdiff/internal/kube"
+	"kdiff/internal/report"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/strings/slices"
+)
+
+func namespacesCmd() *cobra.Command {
+	var (
+		contexts        []string
+		output          string
+		differencesOnly bool
+	)
+
+	command := cobra.Command{
+		Use:   "namespaces",
+		Short: "Print which namespaces exist in which contexts",
+		Long:  "Print a matrix of namespace presence across contexts",
+		Example: "  kdiff namespaces --context staging,prod\n" +
+			"  kdiff namespaces -c staging,prod -d -o json\n" +
+			"  kdiff namespaces -c staging,prod --namespace payments",
+		Run: func(cmd *cobra.Command, args []string) {
+			runNamespaces(contexts, output, differencesOnly)
+		},
+	}
+	command.Flags().StringSliceVarP(&contexts, "context", "c", nil, "Contexts to compare (comma separated)")
+	command.Flags().StringVarP(&output, "output", "o", "table", fmt.Sprintf("Output format (%s)", strings.Join(report.NamespaceFormats, ", ")))
+	command.Flags().BoolVarP(&differencesOnly, "differences-only", "d", false, "Only print namespaces missing in some contexts")
+	command.MarkFlagRequired("context")
+
+	return &command
+}
+
+func runNamespaces(contexts []string, output string, differencesOnly bool) {
+	if !slices.Contains(report.NamespaceFormats, output) {
+		log.Fatalf("Unknown output format %q, must be one of %v", output, report.NamespaceFormats)
+	}
+
+	if err := initKubeClients(contexts); err != nil {
+		log.Fatal(err)
+	}
+
+	presence := kube.GetNamespacePresence(contexts)
+
+	// Limit the output to the namespace set with the kubectl --namespace flag, which is
+	// listed even if it doesn't exist in any context.
+	if namespace := *getKubeConfigFlags().Namespace; namespace != "" {
+		selected := map[string]map[string]bool{namespace: presence[namespace]}
+		if selected[namespace] == nil {
+			selected[namespace] = make(map[string]bool)
+			for _, ctx := range contexts {
+				selected[namespace][ctx] = false
+			}
+		}
+		presence = selected
+	}
+	helpers.HandleError(report.WriteNamespaces(os.Stdout, output, contexts, presence, differencesOnly))
+}
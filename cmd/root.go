@@ -4,13 +4,19 @@ import (
 	"fmt"
 	"kdiff/internal/config"
 	"kdiff/internal/helpers"
+	"kdiff/internal/kube"
 	"kdiff/internal/view"
 	"os"
+	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/strings/slices"
 )
 
 const (
@@ -28,6 +34,7 @@ var (
 	}
 	version, commit = "dev", "dev"
 	appConfig       config.ConfigFlags
+	kubeConfigFlags = newKubeConfigFlags()
 )
 
 func init() {
@@ -40,7 +47,18 @@ func init() {
 	// Initialize config, commands and flags
 	cobra.OnInitialize(initConfig)
 	rootCmd.AddCommand(versionCmd())
+	rootCmd.AddCommand(diffCmd())
+	rootCmd.AddCommand(contextsCmd())
+	rootCmd.AddCommand(namespacesCmd())
 	initConfigFlags()
+
+	// When installed as a kubectl plugin, show usage as "kubectl kdiff ...".
+	if strings.HasPrefix(filepath.Base(os.Args[0]), "kubectl-") {
+		rootCmd.SetUsageTemplate(strings.NewReplacer(
+			"{{.UseLine}}", "kubectl {{.UseLine}}",
+			"{{.CommandPath}}", "kubectl {{.CommandPath}}",
+		).Replace(rootCmd.UsageTemplate()))
+	}
 }
 
 func Execute() {
@@ -55,6 +73,7 @@ func initConfig() {
 
 	// Bind cobra PFlags to viper.
 	viper.BindPFlags(rootCmd.Flags())
+	viper.BindPFlags(rootCmd.PersistentFlags())
 	// Read in environment variables that match.
 	viper.AutomaticEnv()
 
@@ -62,6 +81,18 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	// Register custom resources declared in the config file.
+	var customResources []kube.CustomResource
+	if err := viper.UnmarshalKey("customResources", &customResources); err != nil {
+		log.Fatalf("Invalid customResources in config file: %v", err)
+	}
+	if err := kube.RegisterCustomResources(customResources); err != nil {
+		log.Fatalf("Invalid customResources in config file: %v", err)
+	}
+
+	// Labels & annotations which aren't compared in addition to the default ones.
+	kube.IgnoreMetadataKeys(viper.GetStringSlice("ignoreMetadataKeys"))
 }
 
 func initConfigFlags() {
@@ -80,11 +111,39 @@ func initConfigFlags() {
 		config.DefaultRefreshRate,
 		"Specify the refresh rate (in seconds)",
 	)
-	rootCmd.Flags().StringP(
-		"kubeconfig", "f",
-		config.DefaultKubeconfig,
-		"Path to the kubeconfig file",
-	)
+
+	// Standard kubectl flags. Keep "-f" as shorthand for "--kubeconfig" for backwards compatibility.
+	kubeFlags := pflag.NewFlagSet("kubectl", pflag.ExitOnError)
+	kubeConfigFlags.AddFlags(kubeFlags)
+	kubeFlags.Lookup("kubeconfig").Shorthand = "f"
+	rootCmd.PersistentFlags().AddFlagSet(kubeFlags)
+}
+
+// newKubeConfigFlags returns the subset of standard kubectl flags supported by kdiff.
+// Flags overriding a single cluster or user (e.g. --server, --token) are left out
+// since kdiff connects to all contexts of the kubeconfig file at once.
+func newKubeConfigFlags() *genericclioptions.ConfigFlags {
+	flags := genericclioptions.NewConfigFlags(true)
+	return &genericclioptions.ConfigFlags{
+		KubeConfig:       flags.KubeConfig,
+		Context:          flags.Context,
+		Namespace:        flags.Namespace,
+		Impersonate:      flags.Impersonate,
+		ImpersonateUID:   flags.ImpersonateUID,
+		ImpersonateGroup: flags.ImpersonateGroup,
+		Timeout:          flags.Timeout,
+	}
+}
+
+// getKubeConfigFlags returns kubectl flags. The kubeconfig path falls back to the
+// config file if not set on the command line, otherwise the standard kubectl
+// loading rules ($KUBECONFIG, ~/.kube/config) apply.
+func getKubeConfigFlags() *genericclioptions.ConfigFlags {
+	if *kubeConfigFlags.KubeConfig == "" && viper.InConfig("kubeconfig") {
+		kubeConfig := viper.GetString("kubeconfig")
+		kubeConfigFlags.KubeConfig = &kubeConfig
+	}
+	return kubeConfigFlags
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -93,7 +152,7 @@ func run(cmd *cobra.Command, args []string) {
 		LogFile:     viper.GetString("logFile"),
 		LogLevel:    viper.GetString("logLevel"),
 		RefreshRate: viper.GetInt("refreshRate"),
-		KubeConfig:  viper.GetString("kubeconfig"),
+		KubeConfig:  getKubeConfigFlags(),
 	}
 
 	// Open log file for writing/appending
@@ -105,3 +164,20 @@ func run(cmd *cobra.Command, args []string) {
 
 	view.App(appConfig)
 }
+
+// initKubeClients parses the kubeconfig file and initializes kubernetes clients.
+// It fails if any of the given contexts doesn't exist in the kubeconfig file.
+func initKubeClients(contexts []string) error {
+	var kconfig kube.KubeConfig
+	kconfig.ParseConfig(getKubeConfigFlags())
+	knownContexts, err := kconfig.GetContextNames()
+	if err != nil {
+		return err
+	}
+	for _, ctx := range contexts {
+		if !slices.Contains(knownContexts, ctx) {
+			return fmt.Errorf("context %q not found in kubeconfig", ctx)
+		}
+	}
+	return kconfig.InitializeClients()
+}
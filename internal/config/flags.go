@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 const (
@@ -12,7 +14,6 @@ const (
 )
 
 var (
-	DefaultKubeconfig = filepath.Join(KdiffUserHomeDir(), ".kube", "config")
 	DefaultConfigFile = filepath.Join(KdiffUserHomeDir(), ".config", "kdiff", "config.yaml")
 	DefaultLogFile    = filepath.Join(os.TempDir(), fmt.Sprintf("kdiff-%s.log", KdiffUser()))
 )
@@ -22,5 +23,5 @@ type ConfigFlags struct {
 	LogFile     string
 	LogLevel    string
 	RefreshRate int
-	KubeConfig  string
+	KubeConfig  *genericclioptions.ConfigFlags
 }
@@ -1,6 +1,10 @@
 package helpers
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 func GetUniqueStrings(items []string) []string {
 	var (
@@ -34,3 +38,20 @@ func GetSortedMapKeysBool(inputMap map[string]bool) []string {
 	sort.Strings(keys)
 	return keys
 }
+
+// MaxValueLength is the maximum length of summarized values in reports & the display area.
+const MaxValueLength = 40
+
+// SummarizeValue returns the first line of a value, truncated to maxLength characters.
+// Truncated values end with "…" and multi-line values are suffixed with their line count.
+func SummarizeValue(value string, maxLength int) string {
+	lines := strings.Split(strings.TrimSuffix(value, "\n"), "\n")
+	summary := []rune(lines[0])
+	if len(summary) > maxLength {
+		summary = append(summary[:maxLength], '…')
+	}
+	if len(lines) > 1 {
+		return fmt.Sprintf("%s (%d lines)", string(summary), len(lines))
+	}
+	return string(summary)
+}
@@ -0,0 +1,131 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GetHorizontalPodAutoscalers returns a list of HPAs for a given context & namespace. HPAs
+// are compared by scale target, min & max replicas, the target of each metric & behavior.
+func GetHorizontalPodAutoscalers(ctx, namespace string) ([]*Resource, error) {
+	hpaList, err := clientSets[ctx].AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, hpa := range hpaList.Items {
+		minReplicas := int32(1)
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = *hpa.Spec.MinReplicas
+		}
+		fields := map[string]string{
+			"scaleTargetRef": fmt.Sprintf("%s/%s", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name),
+			"minReplicas":    fmt.Sprint(minReplicas),
+			"maxReplicas":    fmt.Sprint(hpa.Spec.MaxReplicas),
+		}
+		for _, metric := range hpa.Spec.Metrics {
+			name, target := formatMetric(metric)
+			fields[fmt.Sprintf("metrics[%s]", name)] = target
+		}
+		if behavior := hpa.Spec.Behavior; behavior != nil {
+			if behavior.ScaleUp != nil {
+				fields["behavior.scaleUp"] = formatScalingRules(*behavior.ScaleUp)
+			}
+			if behavior.ScaleDown != nil {
+				fields["behavior.scaleDown"] = formatScalingRules(*behavior.ScaleDown)
+			}
+		}
+		returnVar = append(returnVar, newFieldResource(hpa.GetObjectMeta(), fields))
+	}
+	return returnVar, nil
+}
+
+// GetPodDisruptionBudgets returns a list of PDBs for a given context & namespace. PDBs
+// are compared by minAvailable, maxUnavailable & selector.
+func GetPodDisruptionBudgets(ctx, namespace string) ([]*Resource, error) {
+	pdbList, err := clientSets[ctx].PolicyV1().PodDisruptionBudgets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, pdb := range pdbList.Items {
+		fields := map[string]string{
+			"minAvailable":   formatIntOrString(pdb.Spec.MinAvailable),
+			"maxUnavailable": formatIntOrString(pdb.Spec.MaxUnavailable),
+			"selector":       metav1.FormatLabelSelector(pdb.Spec.Selector),
+		}
+		returnVar = append(returnVar, newFieldResource(pdb.GetObjectMeta(), fields))
+	}
+	return returnVar, nil
+}
+
+// formatMetric returns a name identifying a metric (e.g. "Resource/cpu") and its target.
+func formatMetric(metric autoscalingv2.MetricSpec) (string, string) {
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource != nil {
+			return fmt.Sprintf("%s/%s", metric.Type, metric.Resource.Name), formatMetricTarget(metric.Resource.Target)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ContainerResource != nil {
+			return fmt.Sprintf("%s/%s/%s", metric.Type, metric.ContainerResource.Container, metric.ContainerResource.Name), formatMetricTarget(metric.ContainerResource.Target)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods != nil {
+			return fmt.Sprintf("%s/%s", metric.Type, metric.Pods.Metric.Name), formatMetricTarget(metric.Pods.Target)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object != nil {
+			object := metric.Object.DescribedObject
+			return fmt.Sprintf("%s/%s/%s/%s", metric.Type, object.Kind, object.Name, metric.Object.Metric.Name), formatMetricTarget(metric.Object.Target)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External != nil {
+			return fmt.Sprintf("%s/%s", metric.Type, metric.External.Metric.Name), formatMetricTarget(metric.External.Target)
+		}
+	}
+	return string(metric.Type), ""
+}
+
+// formatMetricTarget returns a metric target as "<type> <value>" (e.g. "Utilization 70%").
+func formatMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%s %d%%", target.Type, *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return fmt.Sprintf("%s %s", target.Type, target.AverageValue.String())
+	case target.Value != nil:
+		return fmt.Sprintf("%s %s", target.Type, target.Value.String())
+	}
+	return string(target.Type)
+}
+
+// formatScalingRules returns the stabilization window, select policy & policies of scaling rules.
+func formatScalingRules(rules autoscalingv2.HPAScalingRules) string {
+	var parts []string
+	if rules.StabilizationWindowSeconds != nil {
+		parts = append(parts, fmt.Sprintf("stabilizationWindowSeconds=%d", *rules.StabilizationWindowSeconds))
+	}
+	if rules.SelectPolicy != nil {
+		parts = append(parts, fmt.Sprintf("selectPolicy=%s", *rules.SelectPolicy))
+	}
+	for _, policy := range rules.Policies {
+		parts = append(parts, fmt.Sprintf("%s %d/%ds", policy.Type, policy.Value, policy.PeriodSeconds))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatIntOrString returns the value of an optional int or string, or "" if not set.
+func formatIntOrString(value *intstr.IntOrString) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
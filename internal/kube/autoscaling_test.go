@@ -0,0 +1,78 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestHorizontalPodAutoscaler(maxReplicas, cpuUtilization int32, scaleDownWindow *int32) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "api"},
+			MaxReplicas:    maxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   "cpu",
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &cpuUtilization},
+				},
+			}},
+		},
+	}
+	if scaleDownWindow != nil {
+		hpa.Spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: scaleDownWindow},
+		}
+	}
+	return &hpa
+}
+
+func newTestPodDisruptionBudget(minAvailable intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+		},
+	}
+}
+
+func TestCompareAutoscalersAndDisruptionBudgets(t *testing.T) {
+	window := int32(300)
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newTestHorizontalPodAutoscaler(10, 70, nil),
+		newTestPodDisruptionBudget(intstr.FromInt(1)),
+	)
+	clientSets["prod"] = fake.NewSimpleClientset(
+		newTestHorizontalPodAutoscaler(20, 70, &window),
+		newTestPodDisruptionBudget(intstr.FromString("50%")),
+	)
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"HorizontalPodAutoscaler", "PodDisruptionBudget"}, []string{""})
+	require.NoError(t, err)
+
+	// Test case: Mismatching autoscaling limits & behavior
+	require.True(t, c.IsFieldMismatch("HorizontalPodAutoscaler", "default/api", "maxReplicas"))
+	require.False(t, c.IsFieldMismatch("HorizontalPodAutoscaler", "default/api", "minReplicas"))
+	require.False(t, c.IsFieldMismatch("HorizontalPodAutoscaler", "default/api", "metrics[Resource/cpu]"))
+	require.True(t, c.IsFieldMismatch("HorizontalPodAutoscaler", "default/api", "behavior.scaleDown"))
+	value, _ := c.GetFieldValue("HorizontalPodAutoscaler", "default/api", "metrics[Resource/cpu]", "prod")
+	require.Equal(t, "Utilization 70%", value)
+	value, _ = c.GetFieldValue("HorizontalPodAutoscaler", "default/api", "behavior.scaleDown", "prod")
+	require.Equal(t, "stabilizationWindowSeconds=300", value)
+
+	// Test case: Mismatching disruption budget
+	require.True(t, c.IsFieldMismatch("PodDisruptionBudget", "default/api", "minAvailable"))
+	require.False(t, c.IsFieldMismatch("PodDisruptionBudget", "default/api", "maxUnavailable"))
+	value, _ = c.GetFieldValue("PodDisruptionBudget", "default/api", "selector", "prod")
+	require.Equal(t, "app=api", value)
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"kdiff/internal/helpers"
 	"sort"
+	"time"
 
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -16,9 +19,12 @@ const (
 	// https://github.com/kubernetes/kubernetes/blob/e39a0af5ce0a836b30bd3cce237778fb4557f0cb/staging/src/k8s.io/kubectl/pkg/cmd/cmd.go#L94
 	clientQPS   = 50
 	clientBurst = 300
+
+	// DefaultContextTimeout is the timeout used when checking if a context is reachable.
+	DefaultContextTimeout = 2 * time.Second
 )
 
-var clientSets = make(map[string]*kubernetes.Clientset)
+var clientSets = make(map[string]kubernetes.Interface)
 
 type KubeResources struct {
 	AppsV1Resource *appsv1.AppsV1Interface
@@ -26,9 +32,9 @@ type KubeResources struct {
 }
 
 type KubeConfig struct {
-	configConfig   *clientcmd.ClientConfig
-	kubeConfigPath string
-	Client         Client
+	loadingRules *clientcmd.ClientConfigLoadingRules
+	overrides    clientcmd.ConfigOverrides
+	Client       Client
 }
 
 type Client interface {
@@ -43,86 +49,126 @@ type KubeContext struct {
 	Reachable        bool
 	UnreachableError error
 	ServerVersion    string
+	Latency          time.Duration
+}
+
+// ParseConfig loads kubeconfig files using the same rules as kubectl, honoring the
+// kubeconfig path, impersonation & request timeout from the given flags.
+// Flags selecting a single context or cluster are ignored since all contexts are used.
+func (k *KubeConfig) ParseConfig(flags *genericclioptions.ConfigFlags) {
+	k.loadingRules = clientcmd.NewDefaultClientConfigLoadingRules()
+	if flags.KubeConfig != nil {
+		k.loadingRules.ExplicitPath = *flags.KubeConfig
+	}
+
+	k.overrides = clientcmd.ConfigOverrides{}
+	if flags.Impersonate != nil {
+		k.overrides.AuthInfo.Impersonate = *flags.Impersonate
+	}
+	if flags.ImpersonateUID != nil {
+		k.overrides.AuthInfo.ImpersonateUID = *flags.ImpersonateUID
+	}
+	if flags.ImpersonateGroup != nil {
+		k.overrides.AuthInfo.ImpersonateGroups = *flags.ImpersonateGroup
+	}
+	if flags.Timeout != nil {
+		k.overrides.Timeout = *flags.Timeout
+	}
 }
 
-// ParseConfig loads a kubeconfig file.
-func (k *KubeConfig) ParseConfig(kubeConfigPath string) {
-	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeConfigPath},
-		&clientcmd.ConfigOverrides{})
-	k.configConfig = &clientConfig
-	k.kubeConfigPath = kubeConfigPath
+// getClientConfig returns a client config for the given context.
+func (k *KubeConfig) getClientConfig(ctx string) clientcmd.ClientConfig {
+	overrides := k.overrides
+	overrides.CurrentContext = ctx
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(k.loadingRules, &overrides)
 }
 
 // GetContextNames returns a list of contexts from the kubeconfig file.
-func (k *KubeConfig) GetContextNames() []string {
+func (k *KubeConfig) GetContextNames() ([]string, error) {
 	var listContexts []string
 
-	rawConfig, err := (*k.configConfig).RawConfig()
-	helpers.HandleError(err)
+	rawConfig, err := k.getClientConfig("").RawConfig()
+	if err != nil {
+		return nil, err
+	}
 	for context := range rawConfig.Contexts {
 		listContexts = append(listContexts, context)
 	}
 
 	sort.Strings(listContexts)
-	return listContexts
+	return listContexts, nil
 }
 
 // InitializeClients initializes clients for each context found in kubeconfig file.
-func (k *KubeConfig) InitializeClients() {
-	for _, ctx := range k.GetContextNames() {
-		clientConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-			&clientcmd.ClientConfigLoadingRules{ExplicitPath: k.kubeConfigPath},
-			&clientcmd.ConfigOverrides{
-				CurrentContext: ctx,
-			}).ClientConfig()
-		helpers.HandleError(err)
+func (k *KubeConfig) InitializeClients() error {
+	contexts, err := k.GetContextNames()
+	if err != nil {
+		return err
+	}
+	for _, ctx := range contexts {
+		clientConfig, err := k.getClientConfig(ctx).ClientConfig()
+		if err != nil {
+			return err
+		}
 
 		// Configure rate limits (default value is 5 QPS which is too low)
 		clientConfig.QPS = clientQPS
 		clientConfig.Burst = clientBurst
 
 		clientSets[ctx], err = kubernetes.NewForConfig(clientConfig)
-		helpers.HandleError(err)
+		if err != nil {
+			return err
+		}
+
+		dynamicClients[ctx], err = dynamic.NewForConfig(clientConfig)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-// GetContextInfo returns information about all contexts.
-func (k *KubeConfig) GetContextInfo() []*KubeContext {
+// GetContextInfo returns information about all contexts sorted by name. The timeout
+// limits how long to wait for each context to respond.
+func (k *KubeConfig) GetContextInfo(timeout time.Duration) []*KubeContext {
 	var (
 		allContexts []*KubeContext
 		chanVersion = make(chan *KubeContext)
 	)
-	for _, ctx := range k.GetContextNames() {
+	contexts, err := k.GetContextNames()
+	helpers.HandleError(err)
+	for _, ctx := range contexts {
 		// Although clients are already initialized, we initialize again with a lower timeout.
-		clientConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-			&clientcmd.ClientConfigLoadingRules{ExplicitPath: k.kubeConfigPath},
-			&clientcmd.ConfigOverrides{
-				CurrentContext: ctx,
-				Timeout:        "2",
-			}).ClientConfig()
+		clientConfig, err := k.getClientConfig(ctx).ClientConfig()
 		helpers.HandleError(err)
+		clientConfig.Timeout = timeout
 
 		clientSet, err := kubernetes.NewForConfig(clientConfig)
 		helpers.HandleError(err)
 		go getServerVersion(ctx, clientSet, chanVersion)
 	}
 
-	for range k.GetContextNames() {
+	for range contexts {
 		allContexts = append(allContexts, <-chanVersion)
 	}
 	close(chanVersion)
+
+	sort.Slice(allContexts, func(i, j int) bool {
+		return allContexts[i].Name < allContexts[j].Name
+	})
 	return allContexts
 }
 
 // getServerVersion performs an API call to get the server version for the provided
 // clientSet and sends the result back to a channel.
 func getServerVersion(ctx string, clientSet *kubernetes.Clientset, out chan<- *KubeContext) {
+	start := time.Now()
 	version, err := clientSet.Discovery().ServerVersion()
 
 	var context = KubeContext{
 		Name:             ctx,
 		UnreachableError: err,
+		Latency:          time.Since(start),
 	}
 
 	if err == nil {
@@ -0,0 +1,541 @@
+package kube
+
+import (
+	"fmt"
+	"kdiff/internal/helpers"
+	"sort"
+	"strings"
+)
+
+// ResourceTypes is the list of resource types that can be compared, including
+// custom resources registered with RegisterCustomResources.
+var ResourceTypes = []string{
+	"ClusterRole", "ClusterRoleBinding", "ConfigMap", "CronJob", "DaemonSet", "Deployment",
+	"HorizontalPodAutoscaler", "Ingress", "Job", "PodDisruptionBudget", "Role", "RoleBinding",
+	"Secret", "Service", "StatefulSet",
+}
+
+// workloadResourceTypes is the list of built-in resource types with pod templates.
+var workloadResourceTypes = []string{"CronJob", "DaemonSet", "Deployment", "Job", "StatefulSet"}
+
+// GetWorkloadResourceTypes returns the resource types with pod templates, including custom
+// resources. Other resource types (e.g. Secret or Role) must be compared explicitly since
+// listing them may need further permissions.
+func GetWorkloadResourceTypes() []string {
+	resourceTypes := append([]string{}, workloadResourceTypes...)
+	for rt := range customResources {
+		resourceTypes = append(resourceTypes, rt)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+// clusterScopedResourceTypes is the list of resource types which don't belong to a namespace.
+var clusterScopedResourceTypes = []string{"ClusterRole", "ClusterRoleBinding"}
+
+type getResourcesResult struct {
+	rt        string
+	ctx       string
+	resources []*Resource
+	err       error
+}
+
+// Comparison holds resources fetched from multiple contexts, grouped by
+// resource type, resource key & context.
+type Comparison struct {
+	Contexts      []string
+	ResourceTypes []string
+
+	// resources maps resource type -> resource key (see resourceKey) -> context.
+	resources map[string]map[string]map[string]*Resource
+	// mismatches & fieldMismatches map a resource to its mismatching containers & fields.
+	mismatches      map[string][]string
+	fieldMismatches map[string][]string
+	// dimensionMismatches maps a dimension of a container to its mismatching keys.
+	dimensionMismatches map[dimensionKey][]string
+	dimensions          []string
+	namespaces          []string
+	checkedPods         bool
+	checkedRollouts     bool
+}
+
+// IsResourceType returns true if rt is a known resource type.
+func IsResourceType(rt string) bool {
+	for _, t := range ResourceTypes {
+		if t == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// IsClusterScoped returns true if resources of type rt don't belong to a namespace.
+func IsClusterScoped(rt string) bool {
+	for _, t := range clusterScopedResourceTypes {
+		if t == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// GetResources returns a list of resources of the given type for a context & namespace.
+// The namespace is ignored for cluster scoped resource types.
+func GetResources(rt, ctx, namespace string) ([]*Resource, error) {
+	switch rt {
+	case "Deployment":
+		return GetDeployments(ctx, namespace)
+	case "StatefulSet":
+		return GetStatefulSets(ctx, namespace)
+	case "DaemonSet":
+		return GetDaemonSets(ctx, namespace)
+	case "CronJob":
+		return GetCronJobs(ctx, namespace)
+	case "Job":
+		return GetJobs(ctx, namespace)
+	case "ConfigMap":
+		return GetConfigMaps(ctx, namespace)
+	case "Secret":
+		return GetSecrets(ctx, namespace)
+	case "Service":
+		return GetServices(ctx, namespace)
+	case "Ingress":
+		return GetIngresses(ctx, namespace)
+	case "HorizontalPodAutoscaler":
+		return GetHorizontalPodAutoscalers(ctx, namespace)
+	case "PodDisruptionBudget":
+		return GetPodDisruptionBudgets(ctx, namespace)
+	case "Role":
+		return GetRoles(ctx, namespace)
+	case "RoleBinding":
+		return GetRoleBindings(ctx, namespace)
+	case "ClusterRole":
+		return GetClusterRoles(ctx)
+	case "ClusterRoleBinding":
+		return GetClusterRoleBindings(ctx)
+	}
+	if _, exists := customResources[rt]; exists {
+		return GetCustomResources(rt, ctx, namespace)
+	}
+	return nil, nil
+}
+
+func getResources(rt, ctx, ns string, out chan<- getResourcesResult) {
+	resources, err := GetResources(rt, ctx, ns)
+	if err != nil {
+		err = fmt.Errorf("failed to get %s resources in context '%s': %w", rt, ctx, err)
+	}
+	out <- getResourcesResult{
+		rt:        rt,
+		ctx:       ctx,
+		resources: resources,
+		err:       err,
+	}
+}
+
+// CompareResources concurrently fetches resources for all combinations of the given
+// contexts, resource types & namespaces and identifies mismatching images & fields.
+// An empty namespace ("") fetches resources from all namespaces. It fails with the first
+// error returned by any request.
+func CompareResources(contexts, resourceTypes, namespaces []string) (*Comparison, error) {
+	var (
+		c = Comparison{
+			Contexts:            contexts,
+			ResourceTypes:       resourceTypes,
+			resources:           make(map[string]map[string]map[string]*Resource),
+			mismatches:          make(map[string][]string),
+			fieldMismatches:     make(map[string][]string),
+			namespaces:          namespaces,
+			dimensionMismatches: make(map[dimensionKey][]string),
+		}
+		chanResources = make(chan getResourcesResult)
+		requests      int
+		err           error
+	)
+
+	// Concurrently get resources. Cluster scoped resources are fetched once per context.
+	for _, ctx := range contexts {
+		for _, rt := range resourceTypes {
+			rtNamespaces := namespaces
+			if IsClusterScoped(rt) {
+				rtNamespaces = []string{""}
+			}
+			for _, ns := range rtNamespaces {
+				go getResources(rt, ctx, ns, chanResources)
+				requests++
+			}
+		}
+	}
+
+	// Collect all results, so that no request is left blocked on the channel after an error.
+	for i := 0; i < requests; i++ {
+		result := <-chanResources
+		if result.err != nil {
+			if err == nil {
+				err = result.err
+			}
+			continue
+		}
+
+		for _, res := range result.resources {
+			resourceName := resourceKey(res)
+			if _, exists := c.resources[result.rt]; !exists {
+				c.resources[result.rt] = make(map[string]map[string]*Resource)
+			}
+			if _, exists := c.resources[result.rt][resourceName]; !exists {
+				c.resources[result.rt][resourceName] = make(map[string]*Resource)
+			}
+			c.resources[result.rt][resourceName][result.ctx] = res
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for rt, resourceMap := range c.resources {
+		for resourceName, contextMap := range resourceMap {
+			key := mismatchKey(rt, resourceName)
+
+			// Identify mismatching images of containers existing in any context.
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				var allImages []string
+				for _, res := range contextMap {
+					if !res.hasContainer(containerName) {
+						continue
+					}
+					fullImageName, err := res.GetImage(containerName, true, true, true, true)
+					if err != nil {
+						return nil, err
+					}
+					allImages = append(allImages, fullImageName)
+				}
+				if len(helpers.GetUniqueStrings(allImages)) != 1 {
+					c.mismatches[key] = append(c.mismatches[key], containerName)
+				}
+			}
+			c.compareFields(rt, resourceName)
+		}
+	}
+	return &c, nil
+}
+
+// compareFields identifies fields of a resource with different values, or missing in
+// some of the contexts the resource exists in.
+func (c *Comparison) compareFields(rt, resourceName string) {
+	key := mismatchKey(rt, resourceName)
+	delete(c.fieldMismatches, key)
+	for _, field := range c.GetFieldNames(rt, resourceName) {
+		var allValues []string
+		for _, res := range c.resources[rt][resourceName] {
+			value, exists := res.GetField(field)
+			allValues = append(allValues, fmt.Sprintf("%t:%s", exists, value))
+		}
+		if len(helpers.GetUniqueStrings(allValues)) != 1 {
+			c.fieldMismatches[key] = append(c.fieldMismatches[key], field)
+		}
+	}
+}
+
+func mismatchKey(rt, resourceName string) string {
+	return fmt.Sprintf("%s-%s", rt, resourceName)
+}
+
+// resourceKey identifies a resource across contexts by "<namespace>/<name>", or by its
+// name if it's cluster scoped, so that resources with the same name in different
+// namespaces are compared separately.
+func resourceKey(res *Resource) string {
+	if res.namespace == "" {
+		return res.name
+	}
+	return fmt.Sprintf("%s/%s", res.namespace, res.name)
+}
+
+// SplitResourceKey returns the namespace & name of a resource key returned by
+// GetResourceNames. The namespace is empty for cluster scoped resources.
+func SplitResourceKey(key string) (string, string) {
+	if i := strings.Index(key, "/"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// HasResources returns true if any resource of the given type was found.
+func (c *Comparison) HasResources(rt string) bool {
+	_, exists := c.resources[rt]
+	return exists
+}
+
+// GetResourceNames returns a sorted list of resource keys for a resource type, which are
+// "<namespace>/<name>" (e.g. "default/api") or the name of cluster scoped resources.
+func (c *Comparison) GetResourceNames(rt string) []string {
+	var names []string
+	for name := range c.resources[rt] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetContainerNames returns a list of container names for a resource, sorted by
+// name with init containers first.
+func (c *Comparison) GetContainerNames(rt, resourceName string) []string {
+	var names []string
+	for _, res := range c.resources[rt][resourceName] {
+		names = append(names, res.GetContainers()...)
+	}
+	names = helpers.GetUniqueStrings(names)
+	sort.Slice(names, func(i, j int) bool {
+		initI, initJ := c.IsInitContainer(rt, resourceName, names[i]), c.IsInitContainer(rt, resourceName, names[j])
+		if initI != initJ {
+			return initI
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// IsInitContainer returns true if a container is an init container in any context.
+func (c *Comparison) IsInitContainer(rt, resourceName, containerName string) bool {
+	for _, res := range c.resources[rt][resourceName] {
+		if res.IsInitContainer(containerName) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetContainerLabel returns the container name, suffixed with "(init)" for init containers.
+func (c *Comparison) GetContainerLabel(rt, resourceName, containerName string) string {
+	if c.IsInitContainer(rt, resourceName, containerName) {
+		return fmt.Sprintf("%s (init)", containerName)
+	}
+	return containerName
+}
+
+// GetResource returns the resource containing the given container in a context,
+// or nil if it doesn't exist in that context.
+func (c *Comparison) GetResource(rt, resourceName, containerName, ctx string) *Resource {
+	if res, exists := c.resources[rt][resourceName][ctx]; exists && res.hasContainer(containerName) {
+		return res
+	}
+	return nil
+}
+
+// IsPresent returns true if a resource exists in a context.
+func (c *Comparison) IsPresent(rt, resourceName, ctx string) bool {
+	_, exists := c.resources[rt][resourceName][ctx]
+	return exists
+}
+
+// GetFieldNames returns a sorted list of field names of a resource in any context.
+func (c *Comparison) GetFieldNames(rt, resourceName string) []string {
+	var names []string
+	for _, res := range c.resources[rt][resourceName] {
+		names = append(names, res.GetFields()...)
+	}
+	names = helpers.GetUniqueStrings(names)
+	sort.Strings(names)
+	return names
+}
+
+// GetFieldValue returns the value of a field of a resource in a context and whether it exists.
+func (c *Comparison) GetFieldValue(rt, resourceName, field, ctx string) (string, bool) {
+	if res, exists := c.resources[rt][resourceName][ctx]; exists {
+		return res.GetField(field)
+	}
+	return "", false
+}
+
+// IsFieldMismatch returns true if a field has different values across contexts, or is
+// missing in some of the contexts the resource exists in.
+func (c *Comparison) IsFieldMismatch(rt, resourceName, field string) bool {
+	for _, name := range c.fieldMismatches[mismatchKey(rt, resourceName)] {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMismatch returns true if any container image, compared container dimension or
+// field (including keys of compared workload dimensions) of a resource mismatches across contexts.
+func (c *Comparison) HasMismatch(rt, resourceName string) bool {
+	_, containerMismatch := c.mismatches[mismatchKey(rt, resourceName)]
+	_, fieldMismatch := c.fieldMismatches[mismatchKey(rt, resourceName)]
+	return containerMismatch || fieldMismatch || c.HasDimensionMismatch(rt, resourceName)
+}
+
+// IsMismatch returns true if a container has mismatching images across contexts.
+func (c *Comparison) IsMismatch(rt, resourceName, containerName string) bool {
+	for _, name := range c.mismatches[mismatchKey(rt, resourceName)] {
+		if name == containerName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMissing returns true if a resource doesn't exist in all contexts.
+func (c *Comparison) IsMissing(rt, resourceName string) bool {
+	return len(c.resources[rt][resourceName]) != len(c.Contexts)
+}
+
+// HasAnyMismatch returns true if any resource has mismatching images, compared
+// container dimensions or fields, including keys of compared workload dimensions.
+func (c *Comparison) HasAnyMismatch() bool {
+	return len(c.mismatches) > 0 || len(c.fieldMismatches) > 0 || len(c.dimensionMismatches) > 0
+}
+
+// HasAnyMissing returns true if any resource doesn't exist in all contexts.
+func (c *Comparison) HasAnyMissing() bool {
+	for _, rt := range c.ResourceTypes {
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if c.IsMissing(rt, resourceName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckRunningImages fetches pods of all resources and records the images they are
+// running, so that pods which don't match the declared images can be identified. It
+// fails with the first error returned by any request.
+func (c *Comparison) CheckRunningImages() error {
+	done := make(chan error)
+	for _, ctx := range c.Contexts {
+		for _, rt := range c.ResourceTypes {
+			go func(rt, ctx string) {
+				resources := c.getContextResources(rt, ctx)
+				for _, ns := range c.namespaces {
+					if err := SetRunningImages(rt, ctx, ns, resources); err != nil {
+						done <- fmt.Errorf("failed to get pods of %s resources in context '%s': %w", rt, ctx, err)
+						return
+					}
+				}
+				done <- nil
+			}(rt, ctx)
+		}
+	}
+	var err error
+	for i := 0; i < len(c.Contexts)*len(c.ResourceTypes); i++ {
+		if result := <-done; result != nil && err == nil {
+			err = result
+		}
+	}
+	if err != nil {
+		return err
+	}
+	c.checkedPods = true
+	return nil
+}
+
+// getContextResources returns a list of resources of a type in a context.
+func (c *Comparison) getContextResources(rt, ctx string) []*Resource {
+	var resources []*Resource
+	for _, contextMap := range c.resources[rt] {
+		if res, exists := contextMap[ctx]; exists {
+			resources = append(resources, res)
+		}
+	}
+	return resources
+}
+
+// HasCheckedRunningImages returns true if running images of pods have been checked.
+func (c *Comparison) HasCheckedRunningImages() bool {
+	return c.checkedPods
+}
+
+// IsRunningMismatch returns true if pods of a container in a context don't run the declared image.
+func (c *Comparison) IsRunningMismatch(rt, resourceName, containerName, ctx string) bool {
+	if res := c.GetResource(rt, resourceName, containerName, ctx); res != nil {
+		return res.IsRunningMismatch(containerName)
+	}
+	return false
+}
+
+// HasRunningMismatch returns true if pods of any container of a resource don't run the declared image.
+func (c *Comparison) HasRunningMismatch(rt, resourceName string) bool {
+	for _, res := range c.resources[rt][resourceName] {
+		for _, containerName := range res.GetContainers() {
+			if res.IsRunningMismatch(containerName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasAnyRunningMismatch returns true if pods of any resource don't run the declared image.
+func (c *Comparison) HasAnyRunningMismatch() bool {
+	for _, rt := range c.ResourceTypes {
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if c.HasRunningMismatch(rt, resourceName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckRollouts fetches ReplicaSets of all deployments and records the images & replica
+// counts of every active ReplicaSet, so that in-progress rollouts can be identified. It
+// fails with the first error returned by any request.
+func (c *Comparison) CheckRollouts() error {
+	done := make(chan error)
+	for _, ctx := range c.Contexts {
+		go func(ctx string) {
+			resources := c.getContextResources("Deployment", ctx)
+			if len(resources) > 0 {
+				for _, ns := range c.namespaces {
+					if err := SetRolloutImages(ctx, ns, resources); err != nil {
+						done <- fmt.Errorf("failed to get ReplicaSets in context '%s': %w", ctx, err)
+						return
+					}
+				}
+			}
+			done <- nil
+		}(ctx)
+	}
+	var err error
+	for range c.Contexts {
+		if result := <-done; result != nil && err == nil {
+			err = result
+		}
+	}
+	if err != nil {
+		return err
+	}
+	c.checkedRollouts = true
+	return nil
+}
+
+// HasCheckedRollouts returns true if ReplicaSets of deployments have been checked.
+func (c *Comparison) HasCheckedRollouts() bool {
+	return c.checkedRollouts
+}
+
+// IsRollingOut returns true if a resource has more than one active ReplicaSet in a context.
+func (c *Comparison) IsRollingOut(rt, resourceName, ctx string) bool {
+	if res, exists := c.resources[rt][resourceName][ctx]; exists {
+		return res.IsRollingOut()
+	}
+	return false
+}
+
+// HasRolloutInProgress returns true if a resource is rolling out in any context.
+func (c *Comparison) HasRolloutInProgress(rt, resourceName string) bool {
+	for _, ctx := range c.Contexts {
+		if c.IsRollingOut(rt, resourceName, ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDifferences returns true if a resource has mismatching images across contexts,
+// pods which don't run the declared images or a rollout in progress.
+func (c *Comparison) HasDifferences(rt, resourceName string) bool {
+	return c.HasMismatch(rt, resourceName) || c.HasRunningMismatch(rt, resourceName) ||
+		c.HasRolloutInProgress(rt, resourceName)
+}
@@ -0,0 +1,150 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestDeployment(name string, images map[string]string) *appsv1.Deployment {
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+	}
+	for container, image := range images {
+		deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, corev1.Container{
+			Name:  container,
+			Image: image,
+		})
+	}
+	return &deployment
+}
+
+func TestCompareResources(t *testing.T) {
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newTestDeployment("api", map[string]string{"app": "registry.io/api:1.1", "proxy": "envoy:1.0"}),
+		newTestDeployment("web", map[string]string{"app": "registry.io/web:2.0"}),
+		newTestDeployment("worker", map[string]string{"app": "registry.io/worker:1.0"}),
+	)
+	clientSets["prod"] = fake.NewSimpleClientset(
+		newTestDeployment("api", map[string]string{"app": "registry.io/api:1.0", "proxy": "envoy:1.0"}),
+		newTestDeployment("web", map[string]string{"app": "registry.io/web:2.0"}),
+	)
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"Deployment"}, []string{""})
+	require.NoError(t, err)
+
+	require.True(t, c.HasResources("Deployment"))
+	require.False(t, c.HasResources("DaemonSet"))
+	require.Equal(t, []string{"default/api", "default/web", "default/worker"}, c.GetResourceNames("Deployment"))
+	require.Equal(t, []string{"app", "proxy"}, c.GetContainerNames("Deployment", "default/api"))
+
+	// Test case: Mismatching image
+	require.True(t, c.HasMismatch("Deployment", "default/api"))
+	require.True(t, c.IsMismatch("Deployment", "default/api", "app"))
+	require.False(t, c.IsMismatch("Deployment", "default/api", "proxy"))
+
+	// Test case: Matching images
+	require.False(t, c.HasMismatch("Deployment", "default/web"))
+	require.True(t, c.HasAnyMismatch())
+
+	// Test case: Resource missing in some contexts
+	require.True(t, c.IsMissing("Deployment", "default/worker"))
+	require.False(t, c.IsMissing("Deployment", "default/web"))
+	require.True(t, c.HasAnyMissing())
+
+	image, err := c.GetResource("Deployment", "default/api", "app", "prod").GetImage("app", true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, "registry.io/api:1.0", image)
+	require.Nil(t, c.GetResource("Deployment", "default/api", "app", "dev"))
+}
+
+func TestCompareResourcesInitContainers(t *testing.T) {
+	clientSets["prod"] = fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "registry.io/migrate:1.0"}},
+			Containers:     []corev1.Container{{Name: "app", Image: "registry.io/db:1.0"}},
+		}}},
+	})
+	defer delete(clientSets, "prod")
+
+	c, err := CompareResources([]string{"prod"}, []string{"Deployment"}, []string{""})
+	require.NoError(t, err)
+
+	// Test case: Init containers are sorted first
+	require.Equal(t, []string{"migrate", "app"}, c.GetContainerNames("Deployment", "default/db"))
+	require.True(t, c.IsInitContainer("Deployment", "default/db", "migrate"))
+	require.Equal(t, "migrate (init)", c.GetContainerLabel("Deployment", "default/db", "migrate"))
+	require.Equal(t, "app", c.GetContainerLabel("Deployment", "default/db", "app"))
+}
+
+func TestCompareResourcesError(t *testing.T) {
+	staging := fake.NewSimpleClientset(newTestDeployment("api", map[string]string{"app": "registry.io/api:1.0"}))
+	staging.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "", nil)
+	})
+	clientSets["staging"] = staging
+	clientSets["prod"] = fake.NewSimpleClientset(newTestDeployment("api", map[string]string{"app": "registry.io/api:1.0"}))
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	// Test case: A failed request fails the comparison instead of panicking
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"Deployment", "DaemonSet"}, []string{""})
+	require.Nil(t, c)
+	require.True(t, apierrors.IsForbidden(err))
+	require.ErrorContains(t, err, "context 'staging'")
+}
+
+func TestCompareResourcesNamespaces(t *testing.T) {
+	newNamespacedDeployment := func(namespace, image string) *appsv1.Deployment {
+		deployment := newTestDeployment("api", map[string]string{"app": image})
+		deployment.Namespace = namespace
+		return deployment
+	}
+	newConfigMap := func(namespace, value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: namespace}, Data: map[string]string{"mode": value}}
+	}
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newNamespacedDeployment("team-a", "registry.io/api:1.0"),
+		newNamespacedDeployment("team-b", "registry.io/api:2.0"),
+		newConfigMap("team-a", "fast"),
+		newConfigMap("team-b", "safe"),
+	)
+	clientSets["prod"] = fake.NewSimpleClientset(
+		newNamespacedDeployment("team-a", "registry.io/api:1.0"),
+		newNamespacedDeployment("team-b", "registry.io/api:2.0"),
+		newConfigMap("team-b", "safe"),
+	)
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"Deployment", "ConfigMap"}, []string{"team-a", "team-b"})
+	require.NoError(t, err)
+
+	// Test case: Resources with the same name in different namespaces are compared separately
+	require.Equal(t, []string{"team-a/api", "team-b/api"}, c.GetResourceNames("Deployment"))
+	require.False(t, c.HasAnyMismatch())
+	require.Equal(t, []string{"team-a/config", "team-b/config"}, c.GetResourceNames("ConfigMap"))
+	require.True(t, c.IsMissing("ConfigMap", "team-a/config"))
+	require.False(t, c.IsMissing("ConfigMap", "team-b/config"))
+
+	res := c.GetResource("Deployment", "team-b/api", "app", "prod")
+	require.Equal(t, "team-b", res.GetNamespace())
+	image, err := res.GetImage("app", true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, "registry.io/api:2.0", image)
+
+	namespace, name := SplitResourceKey("team-b/api")
+	require.Equal(t, "team-b", namespace)
+	require.Equal(t, "api", name)
+}
@@ -0,0 +1,38 @@
+package kube
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rootCAConfigMap is created in every namespace with the CA of the cluster, which
+// always differs across clusters.
+const rootCAConfigMap = "kube-root-ca.crt"
+
+// GetConfigMaps returns a list of configMaps for a given context & namespace. Data keys
+// are compared by value & binary data keys by their sha256 checksum.
+func GetConfigMaps(ctx, namespace string) ([]*Resource, error) {
+	configMapList, err := clientSets[ctx].CoreV1().ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, configMap := range configMapList.Items {
+		if configMap.Name == rootCAConfigMap {
+			continue
+		}
+		fields := make(map[string]string)
+		for key, value := range configMap.Data {
+			fields[key] = value
+		}
+		for key, value := range configMap.BinaryData {
+			fields[key] = fmt.Sprintf("<binary, %d bytes, sha256:%x>", len(value), sha256.Sum256(value))
+		}
+		returnVar = append(returnVar, newFieldResource(configMap.GetObjectMeta(), fields))
+	}
+	return returnVar, nil
+}
@@ -0,0 +1,54 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       data,
+	}
+}
+
+func TestCompareConfigMaps(t *testing.T) {
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newTestConfigMap("api", map[string]string{"LOG_LEVEL": "debug", "config.yaml": "port: 80\ntimeout: 5s\n", "REGION": "eu"}),
+		newTestConfigMap("kube-root-ca.crt", map[string]string{"ca.crt": "staging"}),
+	)
+	clientSets["prod"] = fake.NewSimpleClientset(
+		newTestConfigMap("api", map[string]string{"LOG_LEVEL": "info", "config.yaml": "port: 80\ntimeout: 5s\n"}),
+		newTestConfigMap("kube-root-ca.crt", map[string]string{"ca.crt": "prod"}),
+	)
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"ConfigMap"}, []string{""})
+	require.NoError(t, err)
+
+	// Test case: Root CA configMap is ignored
+	require.Equal(t, []string{"default/api"}, c.GetResourceNames("ConfigMap"))
+	require.Empty(t, c.GetContainerNames("ConfigMap", "default/api"))
+	require.Equal(t, []string{"LOG_LEVEL", "REGION", "config.yaml"}, c.GetFieldNames("ConfigMap", "default/api"))
+
+	// Test case: Differing & missing keys
+	require.True(t, c.IsFieldMismatch("ConfigMap", "default/api", "LOG_LEVEL"))
+	require.True(t, c.IsFieldMismatch("ConfigMap", "default/api", "REGION"))
+	require.False(t, c.IsFieldMismatch("ConfigMap", "default/api", "config.yaml"))
+	require.True(t, c.HasMismatch("ConfigMap", "default/api"))
+	require.True(t, c.HasAnyMismatch())
+	require.False(t, c.IsMissing("ConfigMap", "default/api"))
+
+	_, exists := c.GetFieldValue("ConfigMap", "default/api", "REGION", "prod")
+	require.False(t, exists)
+
+	// Test case: Unified diff against the first context
+	require.Equal(t, "--- staging/LOG_LEVEL\n+++ prod/LOG_LEVEL\n@@ -1 +1 @@\n-debug\n+info\n", c.GetFieldDiff("ConfigMap", "default/api", "LOG_LEVEL"))
+	require.Equal(t, "--- staging/REGION\n+++ prod/REGION\n@@ -1 +0,0 @@\n-eu\n", c.GetFieldDiff("ConfigMap", "default/api", "REGION"))
+	require.Empty(t, c.GetFieldDiff("ConfigMap", "default/api", "config.yaml"))
+}
@@ -0,0 +1,89 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	dynamicClients  = make(map[string]dynamic.Interface)
+	customResources = make(map[string]CustomResource)
+)
+
+// CustomResource is a workload resource type declared in the config file, which is
+// fetched with the dynamic client (e.g. Argo Rollouts or OpenKruise CloneSets).
+type CustomResource struct {
+	Kind     string `mapstructure:"kind"`
+	Group    string `mapstructure:"group"`
+	Version  string `mapstructure:"version"`
+	Resource string `mapstructure:"resource"`
+	// PodTemplatePath is the dot separated path to the pod template of the resource
+	// (e.g. "spec.template").
+	PodTemplatePath string `mapstructure:"podTemplatePath"`
+}
+
+// RegisterCustomResources adds custom resources to the list of resource types that can be compared.
+func RegisterCustomResources(crs []CustomResource) error {
+	for _, cr := range crs {
+		if cr.Kind == "" || cr.Version == "" || cr.Resource == "" || cr.PodTemplatePath == "" {
+			return fmt.Errorf("custom resource %q must set kind, version, resource & podTemplatePath", cr.Kind)
+		}
+		if IsResourceType(cr.Kind) {
+			return fmt.Errorf("resource type %q is already defined", cr.Kind)
+		}
+		customResources[cr.Kind] = cr
+		ResourceTypes = append(ResourceTypes, cr.Kind)
+	}
+	sort.Strings(ResourceTypes)
+	return nil
+}
+
+// GetCustomResources returns a list of custom resources of the given type for a context
+// & namespace. Contexts without the custom resource definition have no resources.
+func GetCustomResources(rt, ctx, namespace string) ([]*Resource, error) {
+	cr := customResources[rt]
+	gvr := schema.GroupVersionResource{Group: cr.Group, Version: cr.Version, Resource: cr.Resource}
+
+	resourceList, err := dynamicClients[ctx].Resource(gvr).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, item := range resourceList.Items {
+		podTemplate, err := getPodTemplate(item, cr.PodTemplatePath)
+		if err != nil {
+			return nil, err
+		}
+		returnVar = append(returnVar, newResource(&item, podTemplate))
+	}
+	return returnVar, nil
+}
+
+// getPodTemplate returns the pod template found at a dot separated path of an object.
+func getPodTemplate(obj unstructured.Unstructured, path string) (corev1.PodTemplateSpec, error) {
+	var podTemplate corev1.PodTemplateSpec
+
+	template, found, err := unstructured.NestedMap(obj.Object, strings.Split(path, ".")...)
+	if err != nil {
+		return podTemplate, err
+	}
+	if !found {
+		return podTemplate, fmt.Errorf("pod template %q not found in %s %s/%s", path, obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, &podTemplate)
+	return podTemplate, err
+}
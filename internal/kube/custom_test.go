@@ -0,0 +1,65 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestRollout(name, image string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": image},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestGetCustomResources(t *testing.T) {
+	resourceTypes := ResourceTypes
+	defer func() {
+		ResourceTypes = resourceTypes
+		delete(customResources, "Rollout")
+	}()
+
+	rollout := CustomResource{Kind: "Rollout", Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", PodTemplatePath: "spec.template"}
+	require.NoError(t, RegisterCustomResources([]CustomResource{rollout}))
+	require.True(t, IsResourceType("Rollout"))
+	require.Equal(t, []string{"CronJob", "DaemonSet", "Deployment", "Job", "Rollout", "StatefulSet"}, GetWorkloadResourceTypes())
+
+	// Test case: Invalid custom resources
+	require.Error(t, RegisterCustomResources([]CustomResource{{Kind: "Deployment", Version: "v1", Resource: "deployments", PodTemplatePath: "spec.template"}}))
+	require.Error(t, RegisterCustomResources([]CustomResource{{Kind: "CloneSet"}}))
+
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}: "RolloutList",
+	}
+	for ctx, image := range map[string]string{"staging": "registry.io/api:1.1", "prod": "registry.io/api:1.0"} {
+		clientSets[ctx] = fake.NewSimpleClientset()
+		dynamicClients[ctx] = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, newTestRollout("api", image))
+		defer delete(clientSets, ctx)
+		defer delete(dynamicClients, ctx)
+	}
+
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"Rollout"}, []string{""})
+	require.NoError(t, err)
+	require.Equal(t, []string{"default/api"}, c.GetResourceNames("Rollout"))
+	require.True(t, c.IsMismatch("Rollout", "default/api", "app"))
+
+	image, err := c.GetResource("Rollout", "default/api", "app", "prod").GetImage("app", true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, "registry.io/api:1.0", image)
+}
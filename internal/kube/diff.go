@@ -0,0 +1,86 @@
+package kube
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// GetFieldDiff returns a unified diff of a field between the first context the resource
+// exists in and every other context where the value differs.
+func (c *Comparison) GetFieldDiff(rt, resourceName, field string) string {
+	var (
+		b               strings.Builder
+		baseCtx         string
+		baseValue       string
+		baseValueExists bool
+	)
+	for _, ctx := range c.Contexts {
+		if !c.IsPresent(rt, resourceName, ctx) {
+			continue
+		}
+		value, exists := c.GetFieldValue(rt, resourceName, field, ctx)
+		if baseCtx == "" {
+			baseCtx, baseValue, baseValueExists = ctx, value, exists
+			continue
+		}
+		if value == baseValue && exists == baseValueExists {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(baseValue, baseValueExists),
+			B:        splitLines(value, exists),
+			FromFile: fmt.Sprintf("%s/%s", baseCtx, field),
+			ToFile:   fmt.Sprintf("%s/%s", ctx, field),
+			Context:  3,
+		})
+		if err != nil {
+			return err.Error()
+		}
+		b.WriteString(diff)
+	}
+	return b.String()
+}
+
+// splitLines splits a value into lines, each ending with a newline. Missing values have no lines.
+func splitLines(value string, exists bool) []string {
+	if !exists {
+		return nil
+	}
+	lines := difflib.SplitLines(value)
+	// SplitLines appends a newline to the last line, leaving an empty line for values ending with one.
+	if len(lines) > 1 && lines[len(lines)-1] == "\n" && strings.HasSuffix(value, "\n") {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// GetDimensionDiff returns a listing of the keys of a dimension of a container which differ
+// across contexts, with the value in every context the container exists in.
+func (c *Comparison) GetDimensionDiff(rt, resourceName, containerName, dimension string) string {
+	var (
+		b     strings.Builder
+		width int
+	)
+	for _, ctx := range c.Contexts {
+		if len(ctx) > width {
+			width = len(ctx)
+		}
+	}
+	for _, key := range c.GetDimensionMismatches(rt, resourceName, containerName, dimension) {
+		fmt.Fprintf(&b, "%s\n", key)
+		for _, ctx := range c.Contexts {
+			res := c.GetResource(rt, resourceName, containerName, ctx)
+			if res == nil {
+				continue
+			}
+			value, exists := res.GetContainerField(containerName, dimension, key)
+			if !exists {
+				value = "<missing>"
+			}
+			fmt.Fprintf(&b, "  %-*s  %s\n", width, ctx, value)
+		}
+	}
+	return b.String()
+}
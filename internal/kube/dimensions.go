@@ -0,0 +1,148 @@
+package kube
+
+import (
+	"fmt"
+	"kdiff/internal/helpers"
+	"sort"
+)
+
+// Dimensions is the list of dimensions which can be compared in addition to images.
+var Dimensions = []string{"env", "metadata", "probes", "replicas", "resources"}
+
+// workloadDimensions is the list of dimensions of workloads rather than their containers.
+// Their keys are compared & reported as fields of the workload.
+var workloadDimensions = []string{"metadata", "replicas"}
+
+// dimensionKey identifies a dimension of a container of a resource. Workload dimensions
+// have no key since they're compared as fields of the resource.
+type dimensionKey struct {
+	rt, resourceName, containerName, dimension string
+}
+
+// IsDimension returns true if dimension is a known container or workload dimension.
+func IsDimension(dimension string) bool {
+	for _, d := range Dimensions {
+		if d == dimension {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWorkloadDimension returns true if dimension is compared on workloads rather than their containers.
+func IsWorkloadDimension(dimension string) bool {
+	for _, d := range workloadDimensions {
+		if d == dimension {
+			return true
+		}
+	}
+	return false
+}
+
+// GetContainerFields returns the sorted keys of a dimension of a container.
+func (a *Resource) GetContainerFields(containerName, dimension string) []string {
+	var keys []string
+	for _, container := range a.containers {
+		if container.name == containerName {
+			for key := range container.fields[dimension] {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetContainerField returns the value of a key of a dimension of a container and whether it exists.
+func (a *Resource) GetContainerField(containerName, dimension, key string) (string, bool) {
+	for _, container := range a.containers {
+		if container.name == containerName {
+			value, exists := container.fields[dimension][key]
+			return value, exists
+		}
+	}
+	return "", false
+}
+
+// CompareDimensions identifies containers whose given dimensions differ across contexts.
+// Keys missing in some of the contexts the container exists in are considered different.
+// Keys of workload dimensions are added to the fields of each workload & compared as such.
+func (c *Comparison) CompareDimensions(dimensions []string) {
+	for rt, resourceMap := range c.resources {
+		for resourceName, contextMap := range resourceMap {
+			for _, dimension := range dimensions {
+				if IsWorkloadDimension(dimension) {
+					for _, res := range contextMap {
+						for key, value := range res.workloadFields[dimension] {
+							res.fields[key] = value
+						}
+					}
+					c.compareFields(rt, resourceName)
+				}
+			}
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				for _, dimension := range dimensions {
+					if IsWorkloadDimension(dimension) {
+						continue
+					}
+					key := dimensionKey{rt, resourceName, containerName, dimension}
+					for _, field := range c.GetDimensionFields(rt, resourceName, containerName, dimension) {
+						var allValues []string
+						for _, res := range contextMap {
+							if res.hasContainer(containerName) {
+								value, exists := res.GetContainerField(containerName, dimension, field)
+								allValues = append(allValues, fmt.Sprintf("%t:%s", exists, value))
+							}
+						}
+						if len(helpers.GetUniqueStrings(allValues)) != 1 {
+							c.dimensionMismatches[key] = append(c.dimensionMismatches[key], field)
+						}
+					}
+				}
+			}
+		}
+	}
+	c.dimensions = dimensions
+}
+
+// GetComparedDimensions returns the container & workload dimensions compared in addition to images.
+func (c *Comparison) GetComparedDimensions() []string {
+	return c.dimensions
+}
+
+// GetDimensionFields returns the sorted keys of a dimension of a container in any context.
+func (c *Comparison) GetDimensionFields(rt, resourceName, containerName, dimension string) []string {
+	var keys []string
+	for _, res := range c.resources[rt][resourceName] {
+		keys = append(keys, res.GetContainerFields(containerName, dimension)...)
+	}
+	keys = helpers.GetUniqueStrings(keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// GetDimensionMismatches returns the keys of a dimension of a container which differ across contexts.
+func (c *Comparison) GetDimensionMismatches(rt, resourceName, containerName, dimension string) []string {
+	return c.dimensionMismatches[dimensionKey{rt, resourceName, containerName, dimension}]
+}
+
+// GetMismatchingDimensions returns the compared dimensions of a container which differ across contexts.
+func (c *Comparison) GetMismatchingDimensions(rt, resourceName, containerName string) []string {
+	var dimensions []string
+	for _, dimension := range c.dimensions {
+		if len(c.GetDimensionMismatches(rt, resourceName, containerName, dimension)) > 0 {
+			dimensions = append(dimensions, dimension)
+		}
+	}
+	return dimensions
+}
+
+// HasDimensionMismatch returns true if any compared dimension of any container of a resource differs.
+func (c *Comparison) HasDimensionMismatch(rt, resourceName string) bool {
+	for _, containerName := range c.GetContainerNames(rt, resourceName) {
+		if len(c.GetMismatchingDimensions(rt, resourceName, containerName)) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCompareDimensions(t *testing.T) {
+	defer func() { ignoredMetadataKeys = append([]string{}, defaultIgnoredMetadataKeys...) }()
+	IgnoreMetadataKeys([]string{"argocd.argoproj.io/*"})
+
+	secretRef := &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password",
+	}}
+	httpGet := corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)}}
+	maxSurge := intstr.FromString("25%")
+
+	for _, tc := range []struct {
+		name       string
+		dimensions []string
+		// staging & prod modify the "api" deployment of each context.
+		staging, prod func(*appsv1.Deployment)
+		// containerMismatches maps container dimensions to the differing keys of the "app" container.
+		containerMismatches map[string][]string
+		// fieldMismatches are the differing keys of workload dimensions.
+		fieldMismatches []string
+		check           func(t *testing.T, c *Comparison)
+	}{
+		{
+			// Test case: Differing variable & missing envFrom source, order is ignored
+			name:       "env",
+			dimensions: []string{"env"},
+			staging: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "debug"},
+					{Name: "DB_PASSWORD", ValueFrom: secretRef},
+				}
+				d.Spec.Template.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}},
+				}
+			},
+			prod: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
+					{Name: "DB_PASSWORD", ValueFrom: secretRef},
+					{Name: "LOG_LEVEL", Value: "info"},
+				}
+			},
+			containerMismatches: map[string][]string{"env": {"envFrom[configMap/settings]", "env[LOG_LEVEL]"}},
+			check: func(t *testing.T, c *Comparison) {
+				value, _ := c.GetResource("Deployment", "default/api", "app", "prod").GetContainerField("app", "env", "env[DB_PASSWORD]")
+				require.Equal(t, "secretKeyRef(db/password)", value)
+				require.Equal(t,
+					"envFrom[configMap/settings]\n  staging  configMap/settings\n  prod     <missing>\n"+
+						"env[LOG_LEVEL]\n  staging  debug\n  prod     info\n",
+					c.GetDimensionDiff("Deployment", "default/api", "app", "env"))
+			},
+		},
+		{
+			// Test case: Equal quantities written differently aren't flagged, differing limits are
+			name:       "resources",
+			dimensions: []string{"resources"},
+			staging: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1000m"), corev1.ResourceMemory: resource.MustParse("1024Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				}
+			},
+			prod: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+				}
+			},
+			containerMismatches: map[string][]string{"resources": {"limits.memory"}},
+		},
+		{
+			// Test case: Workload dimension keys are compared as fields
+			name:       "replicas",
+			dimensions: []string{"replicas"},
+			staging: func(d *appsv1.Deployment) {
+				replicas := int32(3)
+				d.Spec.Replicas = &replicas
+				d.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType, RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge}}
+				d.Status.ReadyReplicas = 3
+			},
+			prod: func(d *appsv1.Deployment) {
+				replicas := int32(6)
+				d.Spec.Replicas = &replicas
+				d.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType, RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge}}
+				d.Status.ReadyReplicas = 4
+			},
+			fieldMismatches: []string{"replicas"},
+			check: func(t *testing.T, c *Comparison) {
+				require.Equal(t, []string{"minReadySeconds", "replicas", "strategy.maxSurge", "strategy.type"}, c.GetFieldNames("Deployment", "default/api"))
+				require.Empty(t, c.GetMismatchingDimensions("Deployment", "default/api", "app"))
+
+				// Test case: Desired & ready replicas per context
+				desired, ready, hasReplicas := c.GetReplicas("Deployment", "default/api", "prod")
+				require.True(t, hasReplicas)
+				require.Equal(t, "6", desired)
+				require.Equal(t, int32(4), ready)
+			},
+		},
+		{
+			// Test case: Built-in & configured keys are ignored, the remaining ones are compared as fields
+			name:       "metadata",
+			dimensions: []string{"metadata"},
+			staging: func(d *appsv1.Deployment) {
+				d.Labels = map[string]string{"team": "payments"}
+				d.Annotations = map[string]string{"deployment.kubernetes.io/revision": "4", "argocd.argoproj.io/sync-wave": "1"}
+				d.Spec.Template.Annotations = map[string]string{"sidecar.istio.io/inject": "true", "kubectl.kubernetes.io/restartedAt": "2023-05-01T10:00:00Z"}
+			},
+			prod: func(d *appsv1.Deployment) {
+				d.Labels = map[string]string{"team": "payments"}
+				d.Annotations = map[string]string{"deployment.kubernetes.io/revision": "12", "argocd.argoproj.io/sync-wave": "2"}
+				d.Spec.Template.Annotations = map[string]string{"sidecar.istio.io/inject": "false"}
+			},
+			fieldMismatches: []string{"template.annotations[sidecar.istio.io/inject]"},
+			check: func(t *testing.T, c *Comparison) {
+				require.Equal(t, []string{"labels[team]", "template.annotations[sidecar.istio.io/inject]"}, c.GetFieldNames("Deployment", "default/api"))
+			},
+		},
+		{
+			// Test case: Unset values equal their defaults, differing thresholds & missing probes are flagged
+			name:       "probes",
+			dimensions: []string{"probes"},
+			staging: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].LivenessProbe = &corev1.Probe{ProbeHandler: httpGet, PeriodSeconds: 10, FailureThreshold: 3}
+				d.Spec.Template.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("http")}},
+				}
+			},
+			prod: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].LivenessProbe = &corev1.Probe{ProbeHandler: httpGet, FailureThreshold: 10}
+			},
+			containerMismatches: map[string][]string{"probes": {
+				"liveness.failureThreshold",
+				"readiness.failureThreshold",
+				"readiness.handler",
+				"readiness.initialDelaySeconds",
+				"readiness.periodSeconds",
+				"readiness.successThreshold",
+				"readiness.timeoutSeconds",
+			}},
+			check: func(t *testing.T, c *Comparison) {
+				value, _ := c.GetResource("Deployment", "default/api", "app", "prod").GetContainerField("app", "probes", "liveness.handler")
+				require.Equal(t, "httpGet HTTP :8080/healthz", value)
+				value, _ = c.GetResource("Deployment", "default/api", "app", "staging").GetContainerField("app", "probes", "readiness.handler")
+				require.Equal(t, "tcpSocket :http", value)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			staging := newTestDeployment("api", map[string]string{"app": "registry.io/api:1.0"})
+			tc.staging(staging)
+			prod := newTestDeployment("api", map[string]string{"app": "registry.io/api:1.0"})
+			tc.prod(prod)
+			clientSets["staging"] = fake.NewSimpleClientset(staging)
+			clientSets["prod"] = fake.NewSimpleClientset(prod)
+			defer delete(clientSets, "staging")
+			defer delete(clientSets, "prod")
+
+			c, err := CompareResources([]string{"staging", "prod"}, []string{"Deployment"}, []string{""})
+			require.NoError(t, err)
+
+			// Test case: Dimensions aren't compared unless requested
+			require.False(t, c.HasMismatch("Deployment", "default/api"))
+			require.Empty(t, c.GetFieldNames("Deployment", "default/api"))
+
+			c.CompareDimensions(tc.dimensions)
+			require.Equal(t, tc.dimensions, c.GetComparedDimensions())
+			require.True(t, c.HasMismatch("Deployment", "default/api"))
+			require.False(t, c.IsMismatch("Deployment", "default/api", "app"))
+
+			for dimension, keys := range tc.containerMismatches {
+				require.Equal(t, keys, c.GetDimensionMismatches("Deployment", "default/api", "app", dimension))
+			}
+			var fieldMismatches []string
+			for _, field := range c.GetFieldNames("Deployment", "default/api") {
+				if c.IsFieldMismatch("Deployment", "default/api", field) {
+					fieldMismatches = append(fieldMismatches, field)
+				}
+			}
+			require.Equal(t, tc.fieldMismatches, fieldMismatches)
+
+			if tc.check != nil {
+				tc.check(t, c)
+			}
+		})
+	}
+}
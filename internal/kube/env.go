@@ -0,0 +1,52 @@
+package kube
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getEnvFields returns a field for each environment variable (e.g. "env[LOG_LEVEL]") and
+// each envFrom source (e.g. "envFrom[configMap/settings]") of a container. References to
+// ConfigMaps, Secrets & pod fields are compared by reference, not by the resolved value.
+func getEnvFields(container corev1.Container) map[string]string {
+	fields := make(map[string]string)
+	for _, env := range container.Env {
+		fields[fmt.Sprintf("env[%s]", env.Name)] = formatEnvValue(env)
+	}
+	for _, envFrom := range container.EnvFrom {
+		var source string
+		switch {
+		case envFrom.ConfigMapRef != nil:
+			source = fmt.Sprintf("configMap/%s", envFrom.ConfigMapRef.Name)
+		case envFrom.SecretRef != nil:
+			source = fmt.Sprintf("secret/%s", envFrom.SecretRef.Name)
+		default:
+			continue
+		}
+		value := source
+		if envFrom.Prefix != "" {
+			value = fmt.Sprintf("%s (prefix %s)", source, envFrom.Prefix)
+		}
+		fields[fmt.Sprintf("envFrom[%s]", source)] = value
+	}
+	return fields
+}
+
+// formatEnvValue returns the value of an environment variable, or a description of its source.
+func formatEnvValue(env corev1.EnvVar) string {
+	source := env.ValueFrom
+	switch {
+	case source == nil:
+		return env.Value
+	case source.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("configMapKeyRef(%s/%s)", source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)
+	case source.SecretKeyRef != nil:
+		return fmt.Sprintf("secretKeyRef(%s/%s)", source.SecretKeyRef.Name, source.SecretKeyRef.Key)
+	case source.FieldRef != nil:
+		return fmt.Sprintf("fieldRef(%s)", source.FieldRef.FieldPath)
+	case source.ResourceFieldRef != nil:
+		return fmt.Sprintf("resourceFieldRef(%s/%s)", source.ResourceFieldRef.ContainerName, source.ResourceFieldRef.Resource)
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package kube
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultIgnoredMetadataKeys is the list of labels & annotations set by controllers &
+// tools which differ across contexts without a difference in behavior.
+var defaultIgnoredMetadataKeys = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"kubectl.kubernetes.io/restartedAt",
+	"deployment.kubernetes.io/revision",
+	"deprecated.daemonset.template.generation",
+	"batch.kubernetes.io/controller-uid",
+	"controller-uid",
+}
+
+var ignoredMetadataKeys = append([]string{}, defaultIgnoredMetadataKeys...)
+
+// IgnoreMetadataKeys adds labels & annotations which aren't compared to the default ones.
+// Keys ending in "*" ignore every key with the given prefix (e.g. "argocd.argoproj.io/*").
+func IgnoreMetadataKeys(keys []string) {
+	ignoredMetadataKeys = append(ignoredMetadataKeys, keys...)
+}
+
+// isIgnoredMetadataKey returns true if a label or annotation key isn't compared.
+func isIgnoredMetadataKey(key string) bool {
+	for _, ignored := range ignoredMetadataKeys {
+		if key == ignored || strings.HasSuffix(ignored, "*") && strings.HasPrefix(key, strings.TrimSuffix(ignored, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// getMetadataFields returns a field for each label & annotation of a workload and its pod
+// template (e.g. "labels[team]" or "template.annotations[sidecar.istio.io/inject]"),
+// except the ignored ones.
+func getMetadataFields(meta metav1.Object, template metav1.ObjectMeta) map[string]string {
+	fields := make(map[string]string)
+	addMetadataFields(fields, "labels", meta.GetLabels())
+	addMetadataFields(fields, "annotations", meta.GetAnnotations())
+	addMetadataFields(fields, "template.labels", template.GetLabels())
+	addMetadataFields(fields, "template.annotations", template.GetAnnotations())
+	return fields
+}
+
+func addMetadataFields(fields map[string]string, prefix string, values map[string]string) {
+	for key, value := range values {
+		if !isIgnoredMetadataKey(key) {
+			fields[fmt.Sprintf("%s[%s]", prefix, key)] = value
+		}
+	}
+}
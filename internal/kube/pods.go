@@ -0,0 +1,187 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"kdiff/internal/helpers"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runningImage is the image a container of a pod is running.
+type runningImage struct {
+	image   string
+	imageID string
+}
+
+// GetRunningImageIDs returns the unique image IDs (digests) running in pods for a given container.
+func (a *Resource) GetRunningImageIDs(containerName string) []string {
+	for _, container := range a.containers {
+		if container.name == containerName {
+			var imageIDs []string
+			for _, running := range container.running {
+				if running.imageID != "" {
+					imageIDs = append(imageIDs, running.imageID)
+				}
+			}
+			return helpers.GetUniqueStrings(imageIDs)
+		}
+	}
+	return nil
+}
+
+// IsRunningMismatch returns true if any pod runs a different image than declared
+// for a given container, or if pods run different image digests.
+func (a *Resource) IsRunningMismatch(containerName string) bool {
+	for _, container := range a.containers {
+		if container.name != containerName {
+			continue
+		}
+		if len(a.GetRunningImageIDs(containerName)) > 1 {
+			return true
+		}
+		for _, running := range container.running {
+			if !container.image.matches(running) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matches returns true if a running image matches the declared image. If the declared
+// image has a hash, the running digest must be equal. Otherwise, the normalized repository
+// (e.g. "nginx" is "docker.io/library/nginx") & the tag must be equal.
+func (i image) matches(running runningImage) bool {
+	if i.hash != "" && running.imageID != "" {
+		return running.imageID == fmt.Sprintf("sha256:%s", i.hash)
+	}
+	registry, name, tag, _, err := decomposeImage(running.image)
+	if err != nil {
+		return false
+	}
+	return normalizeRepository(registry, name) == normalizeRepository(i.registry, i.name) &&
+		(tag == i.tag || (tag == "" && i.tag == "latest") || (tag == "latest" && i.tag == ""))
+}
+
+// normalizeRepository returns the full repository of an image including the registry
+// host, which defaults to Docker Hub (e.g. "nginx" -> "docker.io/library/nginx").
+func normalizeRepository(registry, name string) string {
+	repository := name
+	if registry != "" {
+		repository = fmt.Sprintf("%s/%s", registry, name)
+	}
+
+	host, path := "docker.io", repository
+	if parts := strings.SplitN(repository, "/", 2); len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		host, path = parts[0], parts[1]
+	}
+	if host == "index.docker.io" {
+		host = "docker.io"
+	}
+	if host == "docker.io" && !strings.Contains(path, "/") {
+		path = fmt.Sprintf("library/%s", path)
+	}
+	return fmt.Sprintf("%s/%s", host, path)
+}
+
+// getImageDigest returns the digest of a container status image ID
+// (e.g. "docker-pullable://nginx@sha256:abc" -> "sha256:abc").
+func getImageDigest(imageID string) string {
+	if index := strings.LastIndex(imageID, "@"); index >= 0 {
+		return imageID[index+1:]
+	}
+	return imageID
+}
+
+// SetRunningImages fetches pods of the given resources in a context & namespace and
+// records the images their containers are running.
+func SetRunningImages(rt, ctx, namespace string, resources []*Resource) error {
+	owners, err := getPodOwners(rt, ctx, namespace)
+	if err != nil || owners == nil {
+		return err
+	}
+
+	byName := make(map[string]*Resource)
+	for _, res := range resources {
+		byName[fmt.Sprintf("%s/%s", res.namespace, res.name)] = res
+	}
+
+	podList, err := clientSets[ctx].CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		for _, owner := range pod.GetOwnerReferences() {
+			ownerName, exists := owners[fmt.Sprintf("%s/%s/%s", owner.Kind, pod.Namespace, owner.Name)]
+			if !exists && owner.Kind == rt {
+				ownerName, exists = owner.Name, true
+			}
+			if !exists {
+				continue
+			}
+			if res, exists := byName[fmt.Sprintf("%s/%s", pod.Namespace, ownerName)]; exists {
+				res.addRunningImages(pod.Status.InitContainerStatuses)
+				res.addRunningImages(pod.Status.ContainerStatuses)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Resource) addRunningImages(statuses []corev1.ContainerStatus) {
+	for _, status := range statuses {
+		for i := range a.containers {
+			if a.containers[i].name == status.Name && status.Image != "" {
+				a.containers[i].running = append(a.containers[i].running, runningImage{
+					image:   status.Image,
+					imageID: getImageDigest(status.ImageID),
+				})
+			}
+		}
+	}
+}
+
+// getPodOwners returns a map of "<owner kind>/<namespace>/<owner name>" of pods to
+// the name of the resource of the given type which manages them through an
+// intermediate owner (e.g. a ReplicaSet). It returns nil for unsupported resource types.
+func getPodOwners(rt, ctx, namespace string) (map[string]string, error) {
+	owners := make(map[string]string)
+
+	switch rt {
+	case "DaemonSet", "StatefulSet", "Job":
+		// Pods are directly owned by the resource.
+	case "Deployment":
+		replicaSetList, err := clientSets[ctx].AppsV1().ReplicaSets(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, replicaSet := range replicaSetList.Items {
+			for _, owner := range replicaSet.GetOwnerReferences() {
+				if owner.Kind == rt {
+					owners[fmt.Sprintf("ReplicaSet/%s/%s", replicaSet.Namespace, replicaSet.Name)] = owner.Name
+				}
+			}
+		}
+	case "CronJob":
+		jobList, err := clientSets[ctx].BatchV1().Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobList.Items {
+			for _, owner := range job.GetOwnerReferences() {
+				if owner.Kind == rt {
+					owners[fmt.Sprintf("Job/%s/%s", job.Namespace, job.Name)] = owner.Name
+				}
+			}
+		}
+	default:
+		return nil, nil
+	}
+	return owners, nil
+}
@@ -0,0 +1,85 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestPod(name, owner, image, imageID string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Image: image, ImageID: imageID},
+			},
+		},
+	}
+}
+
+func TestCheckRunningImages(t *testing.T) {
+	replicaSet := func(name, deployment string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: deployment}},
+		}}
+	}
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newTestDeployment("api", map[string]string{"app": "registry.io/api:1.1"}),
+		newTestDeployment("web", map[string]string{"app": "registry.io/web:2.0"}),
+		replicaSet("api-1", "api"),
+		replicaSet("api-2", "api"),
+		replicaSet("web-1", "web"),
+		newTestPod("api-1-a", "api-1", "registry.io/api:1.0", "docker-pullable://registry.io/api@sha256:aaa"),
+		newTestPod("api-2-a", "api-2", "registry.io/api:1.1", "docker-pullable://registry.io/api@sha256:bbb"),
+		newTestPod("web-1-a", "web-1", "registry.io/web:2.0", "docker-pullable://registry.io/web@sha256:ccc"),
+	)
+	defer delete(clientSets, "staging")
+
+	c, err := CompareResources([]string{"staging"}, []string{"Deployment"}, []string{""})
+	require.NoError(t, err)
+	require.False(t, c.HasCheckedRunningImages())
+	require.False(t, c.IsRunningMismatch("Deployment", "default/api", "app", "staging"))
+
+	require.NoError(t, c.CheckRunningImages())
+	require.True(t, c.HasCheckedRunningImages())
+
+	// Test case: Pods running an old image
+	require.True(t, c.IsRunningMismatch("Deployment", "default/api", "app", "staging"))
+	require.Equal(t, []string{"sha256:aaa", "sha256:bbb"}, c.GetResource("Deployment", "default/api", "app", "staging").GetRunningImageIDs("app"))
+	require.True(t, c.HasDifferences("Deployment", "default/api"))
+
+	// Test case: Pods running the declared image
+	require.False(t, c.IsRunningMismatch("Deployment", "default/web", "app", "staging"))
+	require.False(t, c.HasDifferences("Deployment", "default/web"))
+}
+
+func TestImageMatches(t *testing.T) {
+	declared := image{name: "nginx", tag: "1.25"}
+	require.True(t, declared.matches(runningImage{image: "docker.io/library/nginx:1.25"}))
+	require.False(t, declared.matches(runningImage{image: "docker.io/library/nginx:1.24"}))
+
+	// Test case: Same name & tag from another registry or path
+	declared = image{registry: "registry.io", name: "api", tag: "1.0"}
+	require.True(t, declared.matches(runningImage{image: "registry.io/api:1.0"}))
+	require.False(t, declared.matches(runningImage{image: "mirror.example.com/other/api:1.0"}))
+	require.False(t, declared.matches(runningImage{image: "registry.io/other/api:1.0"}))
+
+	// Test case: Declared image without tag
+	require.True(t, image{name: "nginx"}.matches(runningImage{image: "nginx:latest"}))
+
+	// Test case: Declared image with hash
+	pinned := image{name: "nginx", tag: "1.25", hash: "abc"}
+	require.True(t, pinned.matches(runningImage{image: "nginx:1.25", imageID: "sha256:abc"}))
+	require.False(t, pinned.matches(runningImage{image: "nginx:1.25", imageID: "sha256:def"}))
+}
@@ -0,0 +1,75 @@
+package kube
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getProbeFields returns fields for the handler, delays, periods & thresholds of the liveness,
+// readiness & startup probes of a container (e.g. "readiness.handler" or "liveness.periodSeconds").
+func getProbeFields(container corev1.Container) map[string]string {
+	fields := make(map[string]string)
+	for name, probe := range map[string]*corev1.Probe{
+		"liveness":  container.LivenessProbe,
+		"readiness": container.ReadinessProbe,
+		"startup":   container.StartupProbe,
+	} {
+		if probe == nil {
+			continue
+		}
+		fields[name+".handler"] = formatProbeHandler(probe.ProbeHandler)
+		fields[name+".initialDelaySeconds"] = fmt.Sprint(probe.InitialDelaySeconds)
+		// Unset values are defaulted by the API server, so they're compared with their defaults.
+		fields[name+".timeoutSeconds"] = fmt.Sprint(defaultInt32(probe.TimeoutSeconds, 1))
+		fields[name+".periodSeconds"] = fmt.Sprint(defaultInt32(probe.PeriodSeconds, 10))
+		fields[name+".successThreshold"] = fmt.Sprint(defaultInt32(probe.SuccessThreshold, 1))
+		fields[name+".failureThreshold"] = fmt.Sprint(defaultInt32(probe.FailureThreshold, 3))
+		if probe.TerminationGracePeriodSeconds != nil {
+			fields[name+".terminationGracePeriodSeconds"] = fmt.Sprint(*probe.TerminationGracePeriodSeconds)
+		}
+	}
+	return fields
+}
+
+// formatProbeHandler returns the type & target of a probe handler, e.g. "httpGet HTTP :8080/healthz".
+func formatProbeHandler(handler corev1.ProbeHandler) string {
+	switch {
+	case handler.Exec != nil:
+		return fmt.Sprintf("exec %s", strings.Join(handler.Exec.Command, " "))
+	case handler.HTTPGet != nil:
+		scheme := handler.HTTPGet.Scheme
+		if scheme == "" {
+			scheme = corev1.URISchemeHTTP
+		}
+		value := fmt.Sprintf("httpGet %s %s:%s%s", scheme, handler.HTTPGet.Host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)
+		var headers []string
+		for _, header := range handler.HTTPGet.HTTPHeaders {
+			headers = append(headers, fmt.Sprintf("%s=%s", header.Name, header.Value))
+		}
+		if len(headers) > 0 {
+			sort.Strings(headers)
+			value = fmt.Sprintf("%s (headers %s)", value, strings.Join(headers, ", "))
+		}
+		return value
+	case handler.TCPSocket != nil:
+		return fmt.Sprintf("tcpSocket %s:%s", handler.TCPSocket.Host, handler.TCPSocket.Port.String())
+	case handler.GRPC != nil:
+		value := fmt.Sprintf("grpc :%d", handler.GRPC.Port)
+		if handler.GRPC.Service != nil && *handler.GRPC.Service != "" {
+			value = fmt.Sprintf("%s %s", value, *handler.GRPC.Service)
+		}
+		return value
+	}
+	return ""
+}
+
+// defaultInt32 returns value, or defaultValue if value is unset.
+func defaultInt32(value, defaultValue int32) int32 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,35 @@
+package kube
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// getResourceFields returns a field for each resource request & limit of a container
+// (e.g. "requests.cpu" or "limits.memory") with its normalized quantity.
+func getResourceFields(container corev1.Container) map[string]string {
+	fields := make(map[string]string)
+	for name, quantity := range container.Resources.Requests {
+		fields[fmt.Sprintf("requests.%s", name)] = normalizeQuantity(name, quantity)
+	}
+	for name, quantity := range container.Resources.Limits {
+		fields[fmt.Sprintf("limits.%s", name)] = normalizeQuantity(name, quantity)
+	}
+	return fields
+}
+
+// normalizeQuantity returns the canonical form of a quantity, so equal quantities written
+// differently (e.g. "1000m" & "1", "1Gi" & "1024Mi") compare equal. CPU is formatted in
+// decimal units & other resources in binary units where they're a multiple of 1024.
+func normalizeQuantity(name corev1.ResourceName, quantity resource.Quantity) string {
+	if name == corev1.ResourceCPU {
+		return resource.NewMilliQuantity(quantity.MilliValue(), resource.DecimalSI).String()
+	}
+	format := resource.DecimalSI
+	if quantity.Value()%1024 == 0 {
+		format = resource.BinarySI
+	}
+	return resource.NewQuantity(quantity.Value(), format).String()
+}
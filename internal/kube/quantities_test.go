@@ -0,0 +1,26 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNormalizeQuantity(t *testing.T) {
+	for _, tc := range []struct {
+		name     corev1.ResourceName
+		quantity string
+		expected string
+	}{
+		{corev1.ResourceCPU, "1000m", "1"},
+		{corev1.ResourceCPU, "0.5", "500m"},
+		{corev1.ResourceMemory, "1024Mi", "1Gi"},
+		{corev1.ResourceMemory, "1073741824", "1Gi"},
+		{corev1.ResourceMemory, "1000M", "1G"},
+		{corev1.ResourceEphemeralStorage, "1.5Gi", "1536Mi"},
+	} {
+		require.Equal(t, tc.expected, normalizeQuantity(tc.name, resource.MustParse(tc.quantity)), tc.quantity)
+	}
+}
@@ -0,0 +1,123 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"kdiff/internal/helpers"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// boundSubject is the value of a subject field of a binding.
+const boundSubject = "bound"
+
+// GetRoles returns a list of roles for a given context & namespace, compared by their normalized rules.
+func GetRoles(ctx, namespace string) ([]*Resource, error) {
+	roleList, err := clientSets[ctx].RbacV1().Roles(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, role := range roleList.Items {
+		returnVar = append(returnVar, newFieldResource(role.GetObjectMeta(), getRuleFields(role.Rules)))
+	}
+	return returnVar, nil
+}
+
+// GetClusterRoles returns a list of cluster roles for a given context, compared by their normalized rules.
+func GetClusterRoles(ctx string) ([]*Resource, error) {
+	clusterRoleList, err := clientSets[ctx].RbacV1().ClusterRoles().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, clusterRole := range clusterRoleList.Items {
+		returnVar = append(returnVar, newFieldResource(clusterRole.GetObjectMeta(), getRuleFields(clusterRole.Rules)))
+	}
+	return returnVar, nil
+}
+
+// GetRoleBindings returns a list of role bindings for a given context & namespace,
+// compared by role & subjects.
+func GetRoleBindings(ctx, namespace string) ([]*Resource, error) {
+	roleBindingList, err := clientSets[ctx].RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, roleBinding := range roleBindingList.Items {
+		returnVar = append(returnVar, newFieldResource(roleBinding.GetObjectMeta(), getBindingFields(roleBinding.RoleRef, roleBinding.Subjects)))
+	}
+	return returnVar, nil
+}
+
+// GetClusterRoleBindings returns a list of cluster role bindings for a given context,
+// compared by role & subjects.
+func GetClusterRoleBindings(ctx string) ([]*Resource, error) {
+	clusterRoleBindingList, err := clientSets[ctx].RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, clusterRoleBinding := range clusterRoleBindingList.Items {
+		returnVar = append(returnVar, newFieldResource(clusterRoleBinding.GetObjectMeta(), getBindingFields(clusterRoleBinding.RoleRef, clusterRoleBinding.Subjects)))
+	}
+	return returnVar, nil
+}
+
+// getRuleFields returns a field for each resource (e.g. "rules[deployments.apps]") or
+// non-resource URL with the sorted verbs allowed on it. Rules are normalized, so that
+// equivalent rules split or ordered differently have the same fields.
+func getRuleFields(rules []rbacv1.PolicyRule) map[string]string {
+	verbs := make(map[string][]string)
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				if group != "" {
+					resource = fmt.Sprintf("%s.%s", resource, group)
+				}
+				if len(rule.ResourceNames) == 0 {
+					verbs[resource] = append(verbs[resource], rule.Verbs...)
+				}
+				for _, resourceName := range rule.ResourceNames {
+					key := fmt.Sprintf("%s/%s", resource, resourceName)
+					verbs[key] = append(verbs[key], rule.Verbs...)
+				}
+			}
+		}
+		for _, url := range rule.NonResourceURLs {
+			verbs[url] = append(verbs[url], rule.Verbs...)
+		}
+	}
+
+	fields := make(map[string]string)
+	for key, keyVerbs := range verbs {
+		keyVerbs = helpers.GetUniqueStrings(keyVerbs)
+		sort.Strings(keyVerbs)
+		fields[fmt.Sprintf("rules[%s]", key)] = strings.Join(keyVerbs, ", ")
+	}
+	return fields
+}
+
+// getBindingFields returns the role of a binding and a field for each subject
+// (e.g. "subjects[ServiceAccount/default/deployer]").
+func getBindingFields(roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) map[string]string {
+	fields := map[string]string{
+		"roleRef": fmt.Sprintf("%s/%s", roleRef.Kind, roleRef.Name),
+	}
+	for _, subject := range subjects {
+		name := subject.Name
+		if subject.Namespace != "" {
+			name = fmt.Sprintf("%s/%s", subject.Namespace, subject.Name)
+		}
+		fields[fmt.Sprintf("subjects[%s/%s]", subject.Kind, name)] = boundSubject
+	}
+	return fields
+}
@@ -0,0 +1,63 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCompareRBAC(t *testing.T) {
+	clientSets["staging"] = fake.NewSimpleClientset(
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "default"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list"}},
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"update"}},
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"settings"}, Verbs: []string{"get"}},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "admins"}},
+		},
+	)
+	clientSets["prod"] = fake.NewSimpleClientset(
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "default"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"update", "list", "get"}},
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"settings"}, Verbs: []string{"get"}},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects: []rbacv1.Subject{
+				{Kind: "Group", Name: "admins"},
+				{Kind: "ServiceAccount", Namespace: "default", Name: "debug"},
+			},
+		},
+	)
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	// Cluster scoped resources are fetched once regardless of namespaces.
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"Role", "ClusterRoleBinding"}, []string{"default", "kube-system"})
+	require.NoError(t, err)
+
+	// Test case: Equivalent rules are normalized
+	require.Equal(t, []string{"rules[configmaps/settings]", "rules[deployments.apps]"}, c.GetFieldNames("Role", "default/deployer"))
+	require.False(t, c.HasMismatch("Role", "default/deployer"))
+	value, _ := c.GetFieldValue("Role", "default/deployer", "rules[deployments.apps]", "staging")
+	require.Equal(t, "get, list, update", value)
+
+	// Test case: Subject bound by hand in one cluster
+	require.True(t, IsClusterScoped("ClusterRoleBinding"))
+	require.False(t, c.IsFieldMismatch("ClusterRoleBinding", "admins", "roleRef"))
+	require.False(t, c.IsFieldMismatch("ClusterRoleBinding", "admins", "subjects[Group/admins]"))
+	require.True(t, c.IsFieldMismatch("ClusterRoleBinding", "admins", "subjects[ServiceAccount/default/debug]"))
+}
@@ -0,0 +1,91 @@
+package kube
+
+import (
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// GetReplicas returns the desired & ready replica counts of a workload and whether
+// it has a replica count (e.g. daemonSets don't).
+func (a *Resource) GetReplicas() (string, int32, bool) {
+	desired, exists := a.workloadFields["replicas"]["replicas"]
+	return desired, a.readyReplicas, exists
+}
+
+// GetReplicas returns the desired & ready replica counts of a resource in a context and
+// whether it has a replica count there.
+func (c *Comparison) GetReplicas(rt, resourceName, ctx string) (string, int32, bool) {
+	if res, exists := c.resources[rt][resourceName][ctx]; exists {
+		return res.GetReplicas()
+	}
+	return "", 0, false
+}
+
+// getDeploymentReplicaFields returns the replica count, update strategy & minReadySeconds of a deployment.
+func getDeploymentReplicaFields(deployment appsv1.Deployment) map[string]string {
+	fields := map[string]string{
+		"replicas":        formatReplicas(deployment.Spec.Replicas),
+		"minReadySeconds": strconv.Itoa(int(deployment.Spec.MinReadySeconds)),
+	}
+	if deployment.Spec.Strategy.Type != "" {
+		fields["strategy.type"] = string(deployment.Spec.Strategy.Type)
+	}
+	if rollingUpdate := deployment.Spec.Strategy.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.MaxSurge != nil {
+			fields["strategy.maxSurge"] = formatIntOrString(rollingUpdate.MaxSurge)
+		}
+		if rollingUpdate.MaxUnavailable != nil {
+			fields["strategy.maxUnavailable"] = formatIntOrString(rollingUpdate.MaxUnavailable)
+		}
+	}
+	return fields
+}
+
+// getStatefulSetReplicaFields returns the replica count, update strategy & minReadySeconds of a statefulSet.
+func getStatefulSetReplicaFields(statefulSet appsv1.StatefulSet) map[string]string {
+	fields := map[string]string{
+		"replicas":        formatReplicas(statefulSet.Spec.Replicas),
+		"minReadySeconds": strconv.Itoa(int(statefulSet.Spec.MinReadySeconds)),
+	}
+	if statefulSet.Spec.UpdateStrategy.Type != "" {
+		fields["strategy.type"] = string(statefulSet.Spec.UpdateStrategy.Type)
+	}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.Partition != nil {
+			fields["strategy.partition"] = strconv.Itoa(int(*rollingUpdate.Partition))
+		}
+		if rollingUpdate.MaxUnavailable != nil {
+			fields["strategy.maxUnavailable"] = formatIntOrString(rollingUpdate.MaxUnavailable)
+		}
+	}
+	return fields
+}
+
+// getDaemonSetReplicaFields returns the update strategy & minReadySeconds of a daemonSet.
+func getDaemonSetReplicaFields(daemonSet appsv1.DaemonSet) map[string]string {
+	fields := map[string]string{
+		"minReadySeconds": strconv.Itoa(int(daemonSet.Spec.MinReadySeconds)),
+	}
+	if daemonSet.Spec.UpdateStrategy.Type != "" {
+		fields["strategy.type"] = string(daemonSet.Spec.UpdateStrategy.Type)
+	}
+	if rollingUpdate := daemonSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.MaxSurge != nil {
+			fields["strategy.maxSurge"] = formatIntOrString(rollingUpdate.MaxSurge)
+		}
+		if rollingUpdate.MaxUnavailable != nil {
+			fields["strategy.maxUnavailable"] = formatIntOrString(rollingUpdate.MaxUnavailable)
+		}
+	}
+	return fields
+}
+
+// formatReplicas returns a replica count, which defaults to 1 if unset.
+func formatReplicas(replicas *int32) string {
+	if replicas == nil {
+		return "1"
+	}
+	return fmt.Sprint(*replicas)
+}
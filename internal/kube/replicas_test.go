@@ -0,0 +1,25 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestGetStatefulSetReplicaFields(t *testing.T) {
+	partition := int32(2)
+	statefulSet := appsv1.StatefulSet{}
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+	}
+
+	// Test case: Unset replicas default to 1
+	require.Equal(t, map[string]string{
+		"replicas":           "1",
+		"strategy.type":      "RollingUpdate",
+		"strategy.partition": "2",
+		"minReadySeconds":    "0",
+	}, getStatefulSetReplicaFields(statefulSet))
+}
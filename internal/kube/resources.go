@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"sort"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -19,21 +20,60 @@ type image struct {
 }
 
 type kContainer struct {
-	name  string
-	image image
+	name    string
+	init    bool
+	image   image
+	running []runningImage
+	rollout []RolloutImage
+	// fields maps a dimension (e.g. "env") to keys & values compared in addition to the image.
+	fields map[string]map[string]string
 }
 
-type AppsV1Resource struct {
+// Resource is a resource compared across contexts by the images of its containers
+// and by its fields, a flat map of keys (e.g. ConfigMap data keys) to values.
+type Resource struct {
 	name       string
+	namespace  string
 	containers []kContainer
+	fields     map[string]string
+	// workloadFields maps a workload dimension (e.g. "replicas") to keys & values which
+	// are added to fields if the dimension is compared.
+	workloadFields map[string]map[string]string
+	// readyReplicas is the number of ready replicas of a workload with a replica count.
+	readyReplicas int32
 }
 
 // GetName returns name of resource.
-func (a *AppsV1Resource) GetName() string {
+func (a *Resource) GetName() string {
 	return a.name
 }
 
-func (a *AppsV1Resource) GetContainers() []string {
+// GetNamespace returns namespace of resource.
+func (a *Resource) GetNamespace() string {
+	return a.namespace
+}
+
+// IsInitContainer returns true if the given container name is an init container.
+func (a *Resource) IsInitContainer(containerName string) bool {
+	for _, container := range a.containers {
+		if container.name == containerName {
+			return container.init
+		}
+	}
+	return false
+}
+
+func (a *Resource) hasContainer(containerName string) bool {
+	for _, container := range a.containers {
+		if container.name == containerName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetContainers returns the names of all containers of a resource.
+func (a *Resource) GetContainers() []string {
 	var containerNames []string
 	for _, container := range a.containers {
 		containerNames = append(containerNames, container.name)
@@ -41,33 +81,29 @@ func (a *AppsV1Resource) GetContainers() []string {
 	return containerNames
 }
 
+// GetFields returns the sorted keys of all fields of a resource.
+func (a *Resource) GetFields() []string {
+	var fields []string
+	for field := range a.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// GetField returns the value of a field and whether it exists.
+func (a *Resource) GetField(field string) (string, bool) {
+	value, exists := a.fields[field]
+	return value, exists
+}
+
 // GetImage returns the image for a given container name.
-func (a *AppsV1Resource) GetImage(containerName string, includeRegistryName bool, includeName bool, includeTag bool, includeHash bool) (string, error) {
+func (a *Resource) GetImage(containerName string, includeRegistryName bool, includeName bool, includeTag bool, includeHash bool) (string, error) {
 	var returnString string
 
 	for _, container := range a.containers {
 		if container.name == containerName {
-			if includeRegistryName && container.image.registry != "" {
-				returnString = container.image.registry
-			}
-			if includeName {
-				if len(returnString) > 0 {
-					returnString = returnString + "/"
-				}
-				returnString = returnString + container.image.name
-			}
-			if includeTag && container.image.tag != "" {
-				if len(returnString) > 0 {
-					returnString = returnString + ":"
-				}
-				returnString = returnString + container.image.tag
-			}
-			if includeHash && container.image.hash != "" {
-				if len(returnString) > 0 {
-					returnString = returnString + "@sha256:"
-				}
-				returnString = returnString + container.image.hash
-			}
+			returnString = container.image.format(includeRegistryName, includeName, includeTag, includeHash)
 		}
 	}
 	if len(returnString) > 0 {
@@ -76,6 +112,43 @@ func (a *AppsV1Resource) GetImage(containerName string, includeRegistryName bool
 	return returnString, fmt.Errorf("lookup failed for container '%s'", containerName)
 }
 
+// format returns the given parts of an image.
+func (i image) format(includeRegistryName bool, includeName bool, includeTag bool, includeHash bool) string {
+	var returnString string
+	if includeRegistryName && i.registry != "" {
+		returnString = i.registry
+	}
+	if includeName {
+		if len(returnString) > 0 {
+			returnString = returnString + "/"
+		}
+		returnString = returnString + i.name
+	}
+	if includeTag && i.tag != "" {
+		if len(returnString) > 0 {
+			returnString = returnString + ":"
+		}
+		returnString = returnString + i.tag
+	}
+	if includeHash && i.hash != "" {
+		if len(returnString) > 0 {
+			returnString = returnString + "@sha256:"
+		}
+		returnString = returnString + i.hash
+	}
+	return returnString
+}
+
+// GetImageComponents returns registry, name, tag and hash of the image for a given container name.
+func (a *Resource) GetImageComponents(containerName string) (string, string, string, string, error) {
+	for _, container := range a.containers {
+		if container.name == containerName {
+			return container.image.registry, container.image.name, container.image.tag, container.image.hash, nil
+		}
+	}
+	return "", "", "", "", fmt.Errorf("lookup failed for container '%s'", containerName)
+}
+
 // GetNamespaces returns a list of namespaces for a give context.
 func GetNamespaces(ctx string) []string {
 	namespaceList, err := clientSets[ctx].CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
@@ -99,31 +172,85 @@ func GetNamespacesForContextList(contexts []string) []string {
 	return helpers.GetUniqueStrings(listNamespaces)
 }
 
+// GetNamespacePresence returns a map of namespace -> context -> presence for the
+// given contexts. Every namespace found in any context has an entry for each context.
+func GetNamespacePresence(contexts []string) map[string]map[string]bool {
+	presence := make(map[string]map[string]bool)
+	for _, ctx := range contexts {
+		for _, ns := range GetNamespaces(ctx) {
+			if _, exists := presence[ns]; !exists {
+				presence[ns] = make(map[string]bool)
+				for _, c := range contexts {
+					presence[ns][c] = false
+				}
+			}
+			presence[ns][ctx] = true
+		}
+	}
+	return presence
+}
+
+// newResource returns a resource with the init containers & containers of the given pod
+// template. Ephemeral containers are ignored since they can't be part of a pod template.
+func newResource(meta metav1.Object, template corev1.PodTemplateSpec) *Resource {
+	resource := Resource{
+		name:           meta.GetName(),
+		namespace:      meta.GetNamespace(),
+		fields:         make(map[string]string),
+		workloadFields: make(map[string]map[string]string),
+	}
+	resource.addContainers(template.Spec.InitContainers, true)
+	resource.addContainers(template.Spec.Containers, false)
+	resource.workloadFields["metadata"] = getMetadataFields(meta, template.ObjectMeta)
+	return &resource
+}
+
+// newFieldResource returns a resource without containers which is compared by its fields.
+func newFieldResource(meta metav1.Object, fields map[string]string) *Resource {
+	return &Resource{
+		name:      meta.GetName(),
+		namespace: meta.GetNamespace(),
+		fields:    fields,
+	}
+}
+
+func (a *Resource) addContainers(containers []corev1.Container, init bool) {
+	for _, container := range containers {
+		registryName, imageName, imageTag, imageHash, err := decomposeImage(container.Image)
+		helpers.HandleError(err)
+		a.containers = append(a.containers, kContainer{
+			name: container.Name,
+			init: init,
+			image: image{
+				registry: registryName,
+				name:     imageName,
+				tag:      imageTag,
+				hash:     imageHash,
+			},
+			fields: map[string]map[string]string{
+				"env":       getEnvFields(container),
+				"probes":    getProbeFields(container),
+				"resources": getResourceFields(container),
+			},
+		})
+	}
+}
+
 // GetDeployments returns a list of deployments for a given context & namespace.
-func GetDeployments(ctx, namespace string) []*AppsV1Resource {
+func GetDeployments(ctx, namespace string) ([]*Resource, error) {
 	deploymentList, err := clientSets[ctx].AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
-	helpers.HandleError(err)
+	if err != nil {
+		return nil, err
+	}
 
-	var returnVar []*AppsV1Resource
+	var returnVar []*Resource
 	for _, deployment := range deploymentList.Items {
-		var resource AppsV1Resource
-		resource.name = deployment.GetObjectMeta().GetName()
-		for _, container := range deployment.Spec.Template.Spec.Containers {
-			registryName, imageName, imageTag, imageHash, err := decomposeImage(container.Image)
-			helpers.HandleError(err)
-			resource.containers = append(resource.containers, kContainer{
-				name: container.Name,
-				image: image{
-					registry: registryName,
-					name:     imageName,
-					tag:      imageTag,
-					hash:     imageHash,
-				},
-			})
-		}
-		returnVar = append(returnVar, &resource)
+		res := newResource(deployment.GetObjectMeta(), deployment.Spec.Template)
+		res.workloadFields["replicas"] = getDeploymentReplicaFields(deployment)
+		res.readyReplicas = deployment.Status.ReadyReplicas
+		returnVar = append(returnVar, res)
 	}
-	return returnVar
+	return returnVar, nil
 }
 
 // decomposeImage returns registry, name, tag and hash of a container image.
@@ -136,55 +263,76 @@ func decomposeImage(image string) (string, string, string, string, error) {
 }
 
 // GetDaemonSets returns a list of daemonSet for a given context & namespace.
-func GetDaemonSets(ctx, namespace string) []*AppsV1Resource {
+func GetDaemonSets(ctx, namespace string) ([]*Resource, error) {
 	daemonSetList, err := clientSets[ctx].AppsV1().DaemonSets(namespace).List(context.TODO(), metav1.ListOptions{})
-	helpers.HandleError(err)
+	if err != nil {
+		return nil, err
+	}
 
-	var returnVar []*AppsV1Resource
+	var returnVar []*Resource
 	for _, daemonSet := range daemonSetList.Items {
-		var resource AppsV1Resource
-		resource.name = daemonSet.GetObjectMeta().GetName()
-		for _, container := range daemonSet.Spec.Template.Spec.Containers {
-			registryName, imageName, imageTag, imageHash, err := decomposeImage(container.Image)
-			helpers.HandleError(err)
-			resource.containers = append(resource.containers, kContainer{
-				name: container.Name,
-				image: image{
-					registry: registryName,
-					name:     imageName,
-					tag:      imageTag,
-					hash:     imageHash,
-				},
-			})
-		}
-		returnVar = append(returnVar, &resource)
+		res := newResource(daemonSet.GetObjectMeta(), daemonSet.Spec.Template)
+		res.workloadFields["replicas"] = getDaemonSetReplicaFields(daemonSet)
+		returnVar = append(returnVar, res)
 	}
-	return returnVar
+	return returnVar, nil
 }
 
 // GetStatefulSets returns a list of statefulSet for a given context & namespace.
-func GetStatefulSets(ctx, namespace string) []*AppsV1Resource {
+func GetStatefulSets(ctx, namespace string) ([]*Resource, error) {
 	statefulSetList, err := clientSets[ctx].AppsV1().StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
-	helpers.HandleError(err)
+	if err != nil {
+		return nil, err
+	}
 
-	var returnVar []*AppsV1Resource
+	var returnVar []*Resource
 	for _, statefulSet := range statefulSetList.Items {
-		var resource AppsV1Resource
-		resource.name = statefulSet.GetObjectMeta().GetName()
-		for _, container := range statefulSet.Spec.Template.Spec.Containers {
-			registryName, imageName, imageTag, imageHash, err := decomposeImage(container.Image)
-			helpers.HandleError(err)
-			resource.containers = append(resource.containers, kContainer{
-				name: container.Name,
-				image: image{
-					registry: registryName,
-					name:     imageName,
-					tag:      imageTag,
-					hash:     imageHash,
-				},
-			})
+		res := newResource(statefulSet.GetObjectMeta(), statefulSet.Spec.Template)
+		res.workloadFields["replicas"] = getStatefulSetReplicaFields(statefulSet)
+		res.readyReplicas = statefulSet.Status.ReadyReplicas
+		returnVar = append(returnVar, res)
+	}
+	return returnVar, nil
+}
+
+// GetCronJobs returns a list of cronJobs for a given context & namespace.
+func GetCronJobs(ctx, namespace string) ([]*Resource, error) {
+	cronJobList, err := clientSets[ctx].BatchV1().CronJobs(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, cronJob := range cronJobList.Items {
+		returnVar = append(returnVar, newResource(cronJob.GetObjectMeta(), cronJob.Spec.JobTemplate.Spec.Template))
+	}
+	return returnVar, nil
+}
+
+// GetJobs returns a list of jobs for a given context & namespace. Jobs created by a
+// cronJob are skipped since their names are generated & differ across contexts.
+func GetJobs(ctx, namespace string) ([]*Resource, error) {
+	jobList, err := clientSets[ctx].BatchV1().Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, job := range jobList.Items {
+		if isOwnedBy(job.GetObjectMeta(), "CronJob") {
+			continue
+		}
+		returnVar = append(returnVar, newResource(job.GetObjectMeta(), job.Spec.Template))
+	}
+	return returnVar, nil
+}
+
+// isOwnedBy returns true if an object has an owner of the given kind.
+func isOwnedBy(meta metav1.Object, kind string) bool {
+	for _, owner := range meta.GetOwnerReferences() {
+		if owner.Kind == kind {
+			return true
 		}
-		returnVar = append(returnVar, &resource)
 	}
-	return returnVar
+	return false
 }
@@ -0,0 +1,69 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func TestGetNamespacePresence(t *testing.T) {
+	clientSets["staging"] = fake.NewSimpleClientset(newTestNamespace("default"), newTestNamespace("feature"))
+	clientSets["prod"] = fake.NewSimpleClientset(newTestNamespace("default"), newTestNamespace("legacy"))
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	presence := GetNamespacePresence([]string{"staging", "prod"})
+
+	require.Equal(t, map[string]map[string]bool{
+		"default": {"staging": true, "prod": true},
+		"feature": {"staging": true, "prod": false},
+		"legacy":  {"staging": false, "prod": true},
+	}, presence)
+}
+
+func TestGetCronJobsAndJobs(t *testing.T) {
+	podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "backup", Image: "registry.io/backup:1.0"}}}
+	clientSets["staging"] = fake.NewSimpleClientset(
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+			}},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Spec:       batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "nightly-28000000",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "nightly"}},
+			},
+			Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+		},
+	)
+	defer delete(clientSets, "staging")
+
+	cronJobs, err := GetCronJobs("staging", "")
+	require.NoError(t, err)
+	require.Len(t, cronJobs, 1)
+	require.Equal(t, "nightly", cronJobs[0].GetName())
+	image, err := cronJobs[0].GetImage("backup", true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, "registry.io/backup:1.0", image)
+
+	// Test case: Jobs created by a cronJob are skipped
+	jobs, err := GetJobs("staging", "")
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, "migrate", jobs[0].GetName())
+}
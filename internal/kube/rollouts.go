@@ -0,0 +1,116 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RolloutImage is an image of a container in an active ReplicaSet of a deployment.
+type RolloutImage struct {
+	Image         string
+	Revision      int
+	Replicas      int32
+	ReadyReplicas int32
+}
+
+// String returns the image with its ready & total replica counts.
+func (r RolloutImage) String() string {
+	return fmt.Sprintf("%s (%d/%d)", r.Image, r.ReadyReplicas, r.Replicas)
+}
+
+// GetRolloutImages returns the images of a container in all active ReplicaSets,
+// newest revision first.
+func (a *Resource) GetRolloutImages(containerName string) []RolloutImage {
+	for _, container := range a.containers {
+		if container.name == containerName {
+			return container.rollout
+		}
+	}
+	return nil
+}
+
+// GetRolloutImageNames returns the given parts of the images of a container in all active
+// ReplicaSets with their ready & total replica counts, newest revision first.
+func (a *Resource) GetRolloutImageNames(containerName string, includeRegistryName bool, includeName bool, includeTag bool, includeHash bool) []string {
+	var names []string
+	for _, rollout := range a.GetRolloutImages(containerName) {
+		registry, name, tag, hash, err := decomposeImage(rollout.Image)
+		if err != nil {
+			names = append(names, rollout.String())
+			continue
+		}
+		imageName := image{registry: registry, name: name, tag: tag, hash: hash}.format(includeRegistryName, includeName, includeTag, includeHash)
+		names = append(names, fmt.Sprintf("%s (%d/%d)", imageName, rollout.ReadyReplicas, rollout.Replicas))
+	}
+	return names
+}
+
+// IsRollingOut returns true if more than one ReplicaSet of a deployment has replicas.
+func (a *Resource) IsRollingOut() bool {
+	for _, container := range a.containers {
+		if len(container.rollout) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRolloutImages fetches ReplicaSets of the given deployments in a context & namespace
+// and records the images & replica counts of all active ReplicaSets.
+func SetRolloutImages(ctx, namespace string, deployments []*Resource) error {
+	byName := make(map[string]*Resource)
+	for _, res := range deployments {
+		byName[fmt.Sprintf("%s/%s", res.namespace, res.name)] = res
+	}
+
+	replicaSetList, err := clientSets[ctx].AppsV1().ReplicaSets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	replicaSets := replicaSetList.Items
+	sort.Slice(replicaSets, func(i, j int) bool {
+		return getRevision(replicaSets[i].GetObjectMeta()) > getRevision(replicaSets[j].GetObjectMeta())
+	})
+
+	for _, replicaSet := range replicaSets {
+		if replicaSet.Status.Replicas == 0 {
+			continue
+		}
+		for _, owner := range replicaSet.GetOwnerReferences() {
+			res, exists := byName[fmt.Sprintf("%s/%s", replicaSet.Namespace, owner.Name)]
+			if owner.Kind != "Deployment" || !exists {
+				continue
+			}
+			podSpec := replicaSet.Spec.Template.Spec
+			for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+				for i := range res.containers {
+					if res.containers[i].name == container.Name {
+						res.containers[i].rollout = append(res.containers[i].rollout, RolloutImage{
+							Image:         container.Image,
+							Revision:      getRevision(replicaSet.GetObjectMeta()),
+							Replicas:      replicaSet.Status.Replicas,
+							ReadyReplicas: replicaSet.Status.ReadyReplicas,
+						})
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getRevision returns the deployment revision of a ReplicaSet, or 0 if unknown.
+func getRevision(meta metav1.Object) int {
+	revision, err := strconv.Atoi(meta.GetAnnotations()[revisionAnnotation])
+	if err != nil {
+		return 0
+	}
+	return revision
+}
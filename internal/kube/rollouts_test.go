@@ -0,0 +1,64 @@
+package kube
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestReplicaSet(name, deployment, image string, revision int, replicas, readyReplicas int32) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			Annotations:     map[string]string{revisionAnnotation: strconv.Itoa(revision)},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: deployment}},
+		},
+		Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: image}},
+		}}},
+		Status: appsv1.ReplicaSetStatus{Replicas: replicas, ReadyReplicas: readyReplicas},
+	}
+}
+
+func TestCheckRollouts(t *testing.T) {
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newTestDeployment("api", map[string]string{"app": "registry.io/api:1.2"}),
+		newTestDeployment("web", map[string]string{"app": "registry.io/web:2.0"}),
+		newTestReplicaSet("api-1", "api", "registry.io/api:1.0", 1, 0, 0),
+		newTestReplicaSet("api-2", "api", "registry.io/api:1.1", 2, 2, 2),
+		newTestReplicaSet("api-3", "api", "registry.io/api:1.2", 3, 1, 0),
+		newTestReplicaSet("web-1", "web", "registry.io/web:2.0", 1, 3, 3),
+	)
+	defer delete(clientSets, "staging")
+
+	c, err := CompareResources([]string{"staging"}, []string{"Deployment"}, []string{""})
+	require.NoError(t, err)
+	require.False(t, c.HasCheckedRollouts())
+	require.False(t, c.HasRolloutInProgress("Deployment", "default/api"))
+
+	require.NoError(t, c.CheckRollouts())
+	require.True(t, c.HasCheckedRollouts())
+
+	// Test case: Rollout in progress, newest revision first & scaled down ReplicaSets ignored
+	require.True(t, c.IsRollingOut("Deployment", "default/api", "staging"))
+	require.True(t, c.HasDifferences("Deployment", "default/api"))
+	require.Equal(t, []RolloutImage{
+		{Image: "registry.io/api:1.2", Revision: 3, Replicas: 1, ReadyReplicas: 0},
+		{Image: "registry.io/api:1.1", Revision: 2, Replicas: 2, ReadyReplicas: 2},
+	}, c.GetResource("Deployment", "default/api", "app", "staging").GetRolloutImages("app"))
+	require.Equal(t, "registry.io/api:1.2 (0/1)", c.GetResource("Deployment", "default/api", "app", "staging").GetRolloutImages("app")[0].String())
+
+	// Test case: Rollout images with only the tag displayed
+	require.Equal(t, []string{"1.2 (0/1)", "1.1 (2/2)"}, c.GetResource("Deployment", "default/api", "app", "staging").GetRolloutImageNames("app", false, false, true, false))
+
+	// Test case: Converged deployment
+	require.False(t, c.IsRollingOut("Deployment", "default/web", "staging"))
+	require.False(t, c.HasDifferences("Deployment", "default/web"))
+	require.Len(t, c.GetResource("Deployment", "default/web", "app", "staging").GetRolloutImages("app"), 1)
+}
@@ -0,0 +1,54 @@
+package kube
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"kdiff/internal/helpers"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// secretSalt is generated on every run, so that hashes of secret values can be compared
+// across contexts but not across runs or against precomputed hashes.
+var secretSalt = newSecretSalt()
+
+func newSecretSalt() []byte {
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	helpers.HandleError(err)
+	return salt
+}
+
+// GetSecrets returns a list of secrets for a given context & namespace. Values are
+// replaced by a salted hash and never stored in plaintext. Service account tokens are
+// ignored since they are unique to every cluster.
+func GetSecrets(ctx, namespace string) ([]*Resource, error) {
+	secretList, err := clientSets[ctx].CoreV1().Secrets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, secret := range secretList.Items {
+		if secret.Type == corev1.SecretTypeServiceAccountToken {
+			continue
+		}
+		fields := make(map[string]string)
+		for key, value := range secret.Data {
+			fields[key] = hashSecretValue(value)
+		}
+		returnVar = append(returnVar, newFieldResource(secret.GetObjectMeta(), fields))
+	}
+	return returnVar, nil
+}
+
+// hashSecretValue returns a truncated HMAC-SHA256 of a secret value keyed with secretSalt.
+func hashSecretValue(value []byte) string {
+	mac := hmac.New(sha256.New, secretSalt)
+	mac.Write(value)
+	return fmt.Sprintf("hmac-sha256:%x", mac.Sum(nil)[:8])
+}
@@ -0,0 +1,58 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestSecret(name string, secretType corev1.SecretType, data map[string]string) *corev1.Secret {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       secretType,
+		Data:       make(map[string][]byte),
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return &secret
+}
+
+func TestCompareSecrets(t *testing.T) {
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newTestSecret("db", corev1.SecretTypeOpaque, map[string]string{"username": "admin", "password": "rotated"}),
+		newTestSecret("default-token", corev1.SecretTypeServiceAccountToken, map[string]string{"token": "staging"}),
+	)
+	clientSets["prod"] = fake.NewSimpleClientset(
+		newTestSecret("db", corev1.SecretTypeOpaque, map[string]string{"username": "admin", "password": "old"}),
+		newTestSecret("default-token", corev1.SecretTypeServiceAccountToken, map[string]string{"token": "prod"}),
+	)
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"Secret"}, []string{""})
+	require.NoError(t, err)
+
+	// Test case: Service account tokens are ignored
+	require.Equal(t, []string{"default/db"}, c.GetResourceNames("Secret"))
+
+	// Test case: Values are compared by hash
+	require.True(t, c.IsFieldMismatch("Secret", "default/db", "password"))
+	require.False(t, c.IsFieldMismatch("Secret", "default/db", "username"))
+
+	// Test case: Plaintext values are never exposed
+	for _, ctx := range c.Contexts {
+		for _, field := range c.GetFieldNames("Secret", "default/db") {
+			value, exists := c.GetFieldValue("Secret", "default/db", field, ctx)
+			require.True(t, exists)
+			require.True(t, strings.HasPrefix(value, "hmac-sha256:"))
+		}
+	}
+	diff := c.GetFieldDiff("Secret", "default/db", "password")
+	require.NotContains(t, diff, "rotated")
+	require.NotContains(t, diff, "old")
+}
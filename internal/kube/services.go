@@ -0,0 +1,111 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"kdiff/internal/helpers"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ingressClassAnnotation is the deprecated annotation used instead of spec.ingressClassName.
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// GetServices returns a list of services for a given context & namespace. Services are
+// compared by type, selector & ports. Cluster IPs & node ports are ignored since they are
+// usually assigned by each cluster.
+func GetServices(ctx, namespace string) ([]*Resource, error) {
+	serviceList, err := clientSets[ctx].CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, service := range serviceList.Items {
+		fields := map[string]string{
+			"type":     string(service.Spec.Type),
+			"selector": formatLabels(service.Spec.Selector),
+		}
+		for _, port := range service.Spec.Ports {
+			name := port.Name
+			if name == "" {
+				name = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+			}
+			fields[fmt.Sprintf("ports[%s]", name)] = fmt.Sprintf("%d/%s -> %s", port.Port, port.Protocol, port.TargetPort.String())
+		}
+		returnVar = append(returnVar, newFieldResource(service.GetObjectMeta(), fields))
+	}
+	return returnVar, nil
+}
+
+// GetIngresses returns a list of ingresses for a given context & namespace. Ingresses are
+// compared by class, the backend of each host & path, the default backend & TLS hosts.
+func GetIngresses(ctx, namespace string) ([]*Resource, error) {
+	ingressList, err := clientSets[ctx].NetworkingV1().Ingresses(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var returnVar []*Resource
+	for _, ingress := range ingressList.Items {
+		class := ingress.Annotations[ingressClassAnnotation]
+		if ingress.Spec.IngressClassName != nil {
+			class = *ingress.Spec.IngressClassName
+		}
+		fields := map[string]string{"class": class}
+
+		if ingress.Spec.DefaultBackend != nil {
+			fields["defaultBackend"] = formatIngressBackend(*ingress.Spec.DefaultBackend)
+		}
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				pathType := ""
+				if path.PathType != nil {
+					pathType = string(*path.PathType)
+				}
+				fields[fmt.Sprintf("rules[%s%s]", rule.Host, path.Path)] = fmt.Sprintf("%s -> %s", pathType, formatIngressBackend(path.Backend))
+			}
+		}
+
+		var tlsHosts []string
+		for _, tls := range ingress.Spec.TLS {
+			tlsHosts = append(tlsHosts, tls.Hosts...)
+		}
+		sort.Strings(tlsHosts)
+		fields["tls.hosts"] = strings.Join(helpers.GetUniqueStrings(tlsHosts), ", ")
+
+		returnVar = append(returnVar, newFieldResource(ingress.GetObjectMeta(), fields))
+	}
+	return returnVar, nil
+}
+
+// formatIngressBackend returns a backend as "<service>:<port>" or "<kind>/<name>".
+func formatIngressBackend(backend networkingv1.IngressBackend) string {
+	if backend.Resource != nil {
+		return fmt.Sprintf("%s/%s", backend.Resource.Kind, backend.Resource.Name)
+	}
+	if backend.Service == nil {
+		return ""
+	}
+	port := backend.Service.Port.Name
+	if port == "" {
+		port = fmt.Sprint(backend.Service.Port.Number)
+	}
+	return fmt.Sprintf("%s:%s", backend.Service.Name, port)
+}
+
+// formatLabels returns labels as a sorted, comma separated list of "key=value".
+func formatLabels(labels map[string]string) string {
+	var pairs []string
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,85 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestService(nodePort int32, ports ...corev1.ServicePort) *corev1.Service {
+	for i := range ports {
+		ports[i].NodePort = nodePort
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeNodePort,
+			Selector: map[string]string{"app": "api", "tier": "backend"},
+			Ports:    ports,
+		},
+	}
+}
+
+func newTestIngress(className string, paths ...string) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: &className,
+			TLS:              []networkingv1.IngressTLS{{Hosts: []string{"api.example.com"}}},
+			Rules:            []networkingv1.IngressRule{{Host: "api.example.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{}}}},
+		},
+	}
+	for _, path := range paths {
+		ingress.Spec.Rules[0].HTTP.Paths = append(ingress.Spec.Rules[0].HTTP.Paths, networkingv1.HTTPIngressPath{
+			Path:     path,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+				Name: "api",
+				Port: networkingv1.ServiceBackendPort{Name: "http"},
+			}},
+		})
+	}
+	return &ingress
+}
+
+func TestCompareServicesAndIngresses(t *testing.T) {
+	http := corev1.ServicePort{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP, TargetPort: intstr.FromInt(8080)}
+	metrics := corev1.ServicePort{Port: 9090, Protocol: corev1.ProtocolTCP, TargetPort: intstr.FromString("metrics")}
+	clientSets["staging"] = fake.NewSimpleClientset(
+		newTestService(30001, http, metrics),
+		newTestIngress("nginx", "/v1", "/v2"),
+	)
+	clientSets["prod"] = fake.NewSimpleClientset(
+		newTestService(30002, http),
+		newTestIngress("nginx", "/v1"),
+	)
+	defer delete(clientSets, "staging")
+	defer delete(clientSets, "prod")
+
+	c, err := CompareResources([]string{"staging", "prod"}, []string{"Service", "Ingress"}, []string{""})
+	require.NoError(t, err)
+
+	// Test case: Forgotten port, node ports are ignored
+	require.Equal(t, []string{"ports[9090/TCP]", "ports[http]", "selector", "type"}, c.GetFieldNames("Service", "default/api"))
+	require.True(t, c.IsFieldMismatch("Service", "default/api", "ports[9090/TCP]"))
+	require.False(t, c.IsFieldMismatch("Service", "default/api", "ports[http]"))
+	value, _ := c.GetFieldValue("Service", "default/api", "ports[http]", "prod")
+	require.Equal(t, "80/TCP -> 8080", value)
+	value, _ = c.GetFieldValue("Service", "default/api", "selector", "prod")
+	require.Equal(t, "app=api, tier=backend", value)
+
+	// Test case: Forgotten path
+	require.Equal(t, []string{"class", "rules[api.example.com/v1]", "rules[api.example.com/v2]", "tls.hosts"}, c.GetFieldNames("Ingress", "default/api"))
+	require.True(t, c.IsFieldMismatch("Ingress", "default/api", "rules[api.example.com/v2]"))
+	require.False(t, c.IsFieldMismatch("Ingress", "default/api", "class"))
+	value, _ = c.GetFieldValue("Ingress", "default/api", "rules[api.example.com/v1]", "prod")
+	require.Equal(t, "Prefix -> api:http", value)
+	value, _ = c.GetFieldValue("Ingress", "default/api", "tls.hosts", "prod")
+	require.Equal(t, "api.example.com", value)
+}
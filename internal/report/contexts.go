@@ -0,0 +1,68 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"kdiff/internal/kube"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ContextFormats is the list of supported output formats for context info.
+var ContextFormats = []string{"table", "json", "yaml"}
+
+type contextDocument struct {
+	Name          string `json:"name"`
+	Reachable     bool   `json:"reachable"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+	LatencyMs     int64  `json:"latencyMs"`
+	Error         string `json:"error,omitempty"`
+}
+
+// WriteContexts writes reachability information of contexts in the given output format.
+func WriteContexts(w io.Writer, format string, contexts []*kube.KubeContext) error {
+	var docs []contextDocument
+	for _, ctx := range contexts {
+		doc := contextDocument{
+			Name:          ctx.Name,
+			Reachable:     ctx.Reachable,
+			ServerVersion: ctx.ServerVersion,
+			LatencyMs:     ctx.Latency.Milliseconds(),
+		}
+		if ctx.UnreachableError != nil {
+			doc.Error = ctx.UnreachableError.Error()
+		}
+		docs = append(docs, doc)
+	}
+
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tREACHABLE\tVERSION\tLATENCY\tERROR")
+		for _, doc := range docs {
+			fmt.Fprintf(tw, "%s\t%t\t%s\t%dms\t%s\n", doc.Name, doc.Reachable, valueOrEmptyCell(doc.ServerVersion), doc.LatencyMs, valueOrEmptyCell(doc.Error))
+		}
+		return tw.Flush()
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(docs)
+	case "yaml":
+		out, err := yaml.Marshal(docs)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	}
+	return fmt.Errorf("unknown output format '%s'", format)
+}
+
+func valueOrEmptyCell(value string) string {
+	if value == "" {
+		return emptyCell
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"kdiff/internal/kube"
+	"strconv"
+)
+
+// WriteCSV writes the image inventory of a comparison with one row per context
+// and container. The separator is used as field delimiter (e.g. ',' or '\t').
+func WriteCSV(w io.Writer, c *kube.Comparison, opts Options, separator rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+
+	header := []string{"context", "namespace", "kind", "resource", "container", "registry", "image", "tag", "digest", "mismatch", "init"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, rt := range c.ResourceTypes {
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if opts.DifferencesOnly && !c.HasDifferences(rt, resourceName) {
+				continue
+			}
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				mismatch := strconv.FormatBool(c.IsMismatch(rt, resourceName, containerName))
+				initContainer := strconv.FormatBool(c.IsInitContainer(rt, resourceName, containerName))
+				for _, ctx := range c.Contexts {
+					res := c.GetResource(rt, resourceName, containerName, ctx)
+					if res == nil {
+						continue
+					}
+					registry, name, tag, hash, err := res.GetImageComponents(containerName)
+					if err != nil {
+						return err
+					}
+					record := []string{ctx, res.GetNamespace(), rt, res.GetName(), containerName, registry, name, tag, formatDigest(hash), mismatch, initContainer}
+					if err := writer.Write(record); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
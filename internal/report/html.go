@@ -0,0 +1,99 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"kdiff/internal/kube"
+	"time"
+)
+
+//go:embed templates/report.html
+var htmlTemplate string
+
+type htmlReport struct {
+	GeneratedAt string
+	Contexts    []string
+	Summary     summary
+	Kinds       []htmlKind
+}
+
+type htmlKind struct {
+	Kind string
+	Rows []htmlRow
+}
+
+type htmlRow struct {
+	Resource  string
+	Container string
+	Cells     []htmlCell
+}
+
+type htmlCell struct {
+	Image      string
+	Mismatch   bool
+	PodsDiffer bool
+	Empty      bool
+}
+
+// WriteHTML writes a comparison as a self-contained HTML report.
+func WriteHTML(w io.Writer, c *kube.Comparison, opts Options) error {
+	tmpl, err := template.New("report").Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+
+	report := htmlReport{
+		GeneratedAt: time.Now().UTC().Format(time.RFC1123),
+		Contexts:    c.Contexts,
+		Summary:     newSummary(c),
+	}
+
+	for _, rt := range c.ResourceTypes {
+		kind := htmlKind{Kind: rt}
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if opts.DifferencesOnly && !c.HasDifferences(rt, resourceName) {
+				continue
+			}
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				row := htmlRow{Resource: resourceName, Container: c.GetContainerLabel(rt, resourceName, containerName)}
+				mismatch := c.IsMismatch(rt, resourceName, containerName)
+				for _, ctx := range c.Contexts {
+					image := getImageCell(c, rt, resourceName, containerName, ctx, opts)
+					row.Cells = append(row.Cells, htmlCell{
+						Image:      image,
+						Mismatch:   mismatch,
+						PodsDiffer: c.IsRunningMismatch(rt, resourceName, containerName, ctx),
+						Empty:      image == emptyCell,
+					})
+				}
+				kind.Rows = append(kind.Rows, row)
+
+				for _, dimensionRow := range getDimensionRows(c, rt, resourceName, containerName) {
+					row := htmlRow{Resource: resourceName, Container: dimensionRow.label}
+					for i, cell := range dimensionRow.cells {
+						row.Cells = append(row.Cells, htmlCell{Image: cell, Mismatch: dimensionRow.deviating[i], Empty: cell == emptyCell})
+					}
+					kind.Rows = append(kind.Rows, row)
+				}
+			}
+			for _, field := range c.GetFieldNames(rt, resourceName) {
+				row := htmlRow{Resource: resourceName, Container: field}
+				deviating := getFieldDeviations(c, rt, resourceName, field)
+				for i, ctx := range c.Contexts {
+					value := getFieldCell(c, rt, resourceName, field, ctx, opts)
+					row.Cells = append(row.Cells, htmlCell{
+						Image:    value,
+						Mismatch: deviating[i],
+						Empty:    value == emptyCell,
+					})
+				}
+				kind.Rows = append(kind.Rows, row)
+			}
+		}
+		if len(kind.Rows) > 0 {
+			report.Kinds = append(report.Kinds, kind)
+		}
+	}
+	return tmpl.Execute(w, report)
+}
@@ -0,0 +1,246 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"kdiff/internal/kube"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// schemaVersion must be bumped on any incompatible change to the
+	// structure of JSON/YAML reports.
+	schemaVersion = "kdiff/v1"
+	schemaKind    = "Comparison"
+)
+
+type comparisonDocument struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Contexts   []string       `json:"contexts"`
+	Kinds      []kindDocument `json:"kinds"`
+}
+
+type kindDocument struct {
+	Kind      string             `json:"kind"`
+	Resources []resourceDocument `json:"resources"`
+}
+
+type resourceDocument struct {
+	Name string `json:"name"`
+	// Namespace is empty for cluster scoped resources.
+	Namespace  string              `json:"namespace,omitempty"`
+	Mismatch   bool                `json:"mismatch"`
+	Missing    bool                `json:"missing"`
+	Containers []containerDocument `json:"containers"`
+	Fields     []fieldDocument     `json:"fields,omitempty"`
+	// ReadyReplicas is keyed by context name and only set for workloads with a replica
+	// count if ready replicas are shown & the "replicas" dimension is compared.
+	ReadyReplicas map[string]int32 `json:"readyReplicas,omitempty"`
+}
+
+type fieldDocument struct {
+	Name     string `json:"name"`
+	Mismatch bool   `json:"mismatch"`
+	// Values is keyed by context name. Contexts without the field are omitted.
+	Values map[string]string `json:"values"`
+}
+
+type containerDocument struct {
+	Name     string `json:"name"`
+	Init     bool   `json:"init"`
+	Mismatch bool   `json:"mismatch"`
+	// Images is keyed by context name. Contexts without the container are omitted.
+	Images map[string]imageDocument `json:"images"`
+	// Running is keyed by context name and only set if pods were checked.
+	Running map[string]runningDocument `json:"running,omitempty"`
+	// Dimensions is keyed by dimension and only set for compared dimensions.
+	Dimensions map[string]dimensionDocument `json:"dimensions,omitempty"`
+	// Rollouts is keyed by context name and only set if rollouts were checked.
+	Rollouts map[string][]rolloutDocument `json:"rollouts,omitempty"`
+}
+
+type dimensionDocument struct {
+	Mismatch bool            `json:"mismatch"`
+	Fields   []fieldDocument `json:"fields"`
+}
+
+type rolloutDocument struct {
+	Image         string `json:"image"`
+	Revision      int    `json:"revision"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+}
+
+type runningDocument struct {
+	ImageIDs []string `json:"imageIDs"`
+	Mismatch bool     `json:"mismatch"`
+}
+
+type imageDocument struct {
+	Image    string `json:"image"`
+	Registry string `json:"registry"`
+	Name     string `json:"name"`
+	Tag      string `json:"tag"`
+	Digest   string `json:"digest"`
+}
+
+// WriteJSON writes a comparison as an indented JSON document.
+func WriteJSON(w io.Writer, c *kube.Comparison, opts Options) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(newComparisonDocument(c, opts))
+}
+
+// WriteYAML writes a comparison as a YAML document.
+func WriteYAML(w io.Writer, c *kube.Comparison, opts Options) error {
+	out, err := yaml.Marshal(newComparisonDocument(c, opts))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+func newComparisonDocument(c *kube.Comparison, opts Options) comparisonDocument {
+	doc := comparisonDocument{
+		APIVersion: schemaVersion,
+		Kind:       schemaKind,
+		Contexts:   c.Contexts,
+		Kinds:      []kindDocument{},
+	}
+
+	for _, rt := range c.ResourceTypes {
+		kindDoc := kindDocument{Kind: rt, Resources: []resourceDocument{}}
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if opts.DifferencesOnly && !c.HasDifferences(rt, resourceName) {
+				continue
+			}
+			namespace, name := kube.SplitResourceKey(resourceName)
+			resourceDoc := resourceDocument{
+				Name:       name,
+				Namespace:  namespace,
+				Mismatch:   c.HasMismatch(rt, resourceName),
+				Missing:    c.IsMissing(rt, resourceName),
+				Containers: []containerDocument{},
+			}
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				containerDoc := containerDocument{
+					Name:     containerName,
+					Init:     c.IsInitContainer(rt, resourceName, containerName),
+					Mismatch: c.IsMismatch(rt, resourceName, containerName),
+					Images:   make(map[string]imageDocument),
+				}
+				for _, ctx := range c.Contexts {
+					res := c.GetResource(rt, resourceName, containerName, ctx)
+					if imageDoc, ok := newImageDocument(res, containerName); ok {
+						containerDoc.Images[ctx] = imageDoc
+					}
+					if res != nil && c.HasCheckedRunningImages() {
+						if containerDoc.Running == nil {
+							containerDoc.Running = make(map[string]runningDocument)
+						}
+						containerDoc.Running[ctx] = runningDocument{
+							ImageIDs: append([]string{}, res.GetRunningImageIDs(containerName)...),
+							Mismatch: res.IsRunningMismatch(containerName),
+						}
+					}
+					if res != nil && c.HasCheckedRollouts() && rt == "Deployment" {
+						if containerDoc.Rollouts == nil {
+							containerDoc.Rollouts = make(map[string][]rolloutDocument)
+						}
+						containerDoc.Rollouts[ctx] = []rolloutDocument{}
+						for _, rollout := range res.GetRolloutImages(containerName) {
+							containerDoc.Rollouts[ctx] = append(containerDoc.Rollouts[ctx], rolloutDocument{
+								Image:         rollout.Image,
+								Revision:      rollout.Revision,
+								Replicas:      rollout.Replicas,
+								ReadyReplicas: rollout.ReadyReplicas,
+							})
+						}
+					}
+				}
+				for _, dimension := range c.GetComparedDimensions() {
+					// Keys of workload dimensions are reported as fields of the resource.
+					if kube.IsWorkloadDimension(dimension) {
+						continue
+					}
+					if containerDoc.Dimensions == nil {
+						containerDoc.Dimensions = make(map[string]dimensionDocument)
+					}
+					containerDoc.Dimensions[dimension] = newDimensionDocument(c, rt, resourceName, containerName, dimension)
+				}
+				resourceDoc.Containers = append(resourceDoc.Containers, containerDoc)
+			}
+			for _, field := range c.GetFieldNames(rt, resourceName) {
+				fieldDoc := fieldDocument{
+					Name:     field,
+					Mismatch: c.IsFieldMismatch(rt, resourceName, field),
+					Values:   make(map[string]string),
+				}
+				for _, ctx := range c.Contexts {
+					if value, exists := c.GetFieldValue(rt, resourceName, field, ctx); exists {
+						fieldDoc.Values[ctx] = value
+					}
+				}
+				resourceDoc.Fields = append(resourceDoc.Fields, fieldDoc)
+			}
+			if showReadyReplicas(c, opts) {
+				for _, ctx := range c.Contexts {
+					if _, ready, hasReplicas := c.GetReplicas(rt, resourceName, ctx); hasReplicas {
+						if resourceDoc.ReadyReplicas == nil {
+							resourceDoc.ReadyReplicas = make(map[string]int32)
+						}
+						resourceDoc.ReadyReplicas[ctx] = ready
+					}
+				}
+			}
+			kindDoc.Resources = append(kindDoc.Resources, resourceDoc)
+		}
+		doc.Kinds = append(doc.Kinds, kindDoc)
+	}
+	return doc
+}
+
+func newImageDocument(res *kube.Resource, containerName string) (imageDocument, bool) {
+	if res == nil {
+		return imageDocument{}, false
+	}
+	registry, name, tag, hash, err := res.GetImageComponents(containerName)
+	if err != nil {
+		return imageDocument{}, false
+	}
+	image, _ := res.GetImage(containerName, true, true, true, true)
+
+	doc := imageDocument{
+		Image:    image,
+		Registry: registry,
+		Name:     name,
+		Tag:      tag,
+		Digest:   formatDigest(hash),
+	}
+	return doc, true
+}
+
+func newDimensionDocument(c *kube.Comparison, rt, resourceName, containerName, dimension string) dimensionDocument {
+	mismatches := c.GetDimensionMismatches(rt, resourceName, containerName, dimension)
+	doc := dimensionDocument{Mismatch: len(mismatches) > 0, Fields: []fieldDocument{}}
+	for _, key := range c.GetDimensionFields(rt, resourceName, containerName, dimension) {
+		fieldDoc := fieldDocument{Name: key, Values: make(map[string]string)}
+		for _, mismatch := range mismatches {
+			if mismatch == key {
+				fieldDoc.Mismatch = true
+			}
+		}
+		for _, ctx := range c.Contexts {
+			if res := c.GetResource(rt, resourceName, containerName, ctx); res != nil {
+				if value, exists := res.GetContainerField(containerName, dimension, key); exists {
+					fieldDoc.Values[ctx] = value
+				}
+			}
+		}
+		doc.Fields = append(doc.Fields, fieldDoc)
+	}
+	return doc
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"kdiff/internal/kube"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes a comparison as a JUnit XML report. Each container & field is a
+// test case which fails if its image or value differs across contexts.
+func WriteJUnit(w io.Writer, c *kube.Comparison, opts Options) error {
+	suites := junitTestSuites{Name: appName}
+
+	for _, rt := range c.ResourceTypes {
+		suite := junitTestSuite{Name: rt}
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if opts.DifferencesOnly && !c.HasDifferences(rt, resourceName) {
+				continue
+			}
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				testCase := junitTestCase{
+					ClassName: fmt.Sprintf("%s.%s", rt, resourceName),
+					Name:      c.GetContainerLabel(rt, resourceName, containerName),
+				}
+				if c.IsMismatch(rt, resourceName, containerName) {
+					var images []string
+					for _, ctx := range c.Contexts {
+						images = append(images, fmt.Sprintf("%s: %s", ctx, getImageCell(c, rt, resourceName, containerName, ctx, opts)))
+					}
+					testCase.Failure = &junitFailure{
+						Message: fmt.Sprintf("image mismatch across contexts (%s)", strings.Join(images, ", ")),
+						Type:    "ImageMismatch",
+						Text:    strings.Join(images, "\n"),
+					}
+					suite.Failures++
+				} else if dimensions := c.GetMismatchingDimensions(rt, resourceName, containerName); len(dimensions) > 0 {
+					var diffs []string
+					for _, dimension := range dimensions {
+						diffs = append(diffs, c.GetDimensionDiff(rt, resourceName, containerName, dimension))
+					}
+					testCase.Failure = &junitFailure{
+						Message: fmt.Sprintf("%s mismatch across contexts", strings.Join(dimensions, ", ")),
+						Type:    "DimensionMismatch",
+						Text:    strings.Join(diffs, ""),
+					}
+					suite.Failures++
+				} else if isAnyRunningMismatch(c, rt, resourceName, containerName) {
+					var contexts []string
+					for _, ctx := range c.Contexts {
+						if c.IsRunningMismatch(rt, resourceName, containerName, ctx) {
+							contexts = append(contexts, ctx)
+						}
+					}
+					testCase.Failure = &junitFailure{
+						Message: fmt.Sprintf("pods don't run the declared image in: %s", strings.Join(contexts, ", ")),
+						Type:    "RunningImageMismatch",
+						Text:    strings.Join(contexts, "\n"),
+					}
+					suite.Failures++
+				}
+				suite.Cases = append(suite.Cases, testCase)
+				suite.Tests++
+			}
+			for _, field := range c.GetFieldNames(rt, resourceName) {
+				testCase := junitTestCase{
+					ClassName: fmt.Sprintf("%s.%s", rt, resourceName),
+					Name:      field,
+				}
+				if c.IsFieldMismatch(rt, resourceName, field) {
+					testCase.Failure = &junitFailure{
+						Message: "value mismatch across contexts",
+						Type:    "ValueMismatch",
+						Text:    c.GetFieldDiff(rt, resourceName, field),
+					}
+					suite.Failures++
+				}
+				suite.Cases = append(suite.Cases, testCase)
+				suite.Tests++
+			}
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
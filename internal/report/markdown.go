@@ -0,0 +1,92 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"kdiff/internal/kube"
+	"strings"
+)
+
+// WriteMarkdown writes a comparison as a GitHub flavored markdown report.
+func WriteMarkdown(w io.Writer, c *kube.Comparison, opts Options) error {
+	s := newSummary(c)
+
+	var contexts []string
+	for _, ctx := range c.Contexts {
+		contexts = append(contexts, fmt.Sprintf("`%s`", ctx))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## kdiff report\n\n")
+	fmt.Fprintf(&b, "Contexts: %s\n\n", strings.Join(contexts, ", "))
+	fmt.Fprintf(&b, "- :white_check_mark: **%d** in sync\n", s.InSync)
+	fmt.Fprintf(&b, "- :x: **%d** drifted\n", s.Drifted)
+	fmt.Fprintf(&b, "- :warning: **%d** missing in some contexts\n\n", s.Missing)
+
+	header := append([]string{"Kind", "Resource", "Container/Key"}, c.Contexts...)
+	writeMarkdownRow(&b, header)
+	var separator []string
+	for range header {
+		separator = append(separator, "---")
+	}
+	writeMarkdownRow(&b, separator)
+
+	for _, rt := range c.ResourceTypes {
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if opts.DifferencesOnly && !c.HasDifferences(rt, resourceName) {
+				continue
+			}
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				row := []string{rt, resourceName, c.GetContainerLabel(rt, resourceName, containerName)}
+				mismatch := c.IsMismatch(rt, resourceName, containerName)
+				for _, ctx := range c.Contexts {
+					image := getImageCell(c, rt, resourceName, containerName, ctx, opts)
+					if mismatch && image != emptyCell {
+						image = fmt.Sprintf("**`%s`**", image)
+					} else if image != emptyCell {
+						image = fmt.Sprintf("`%s`", image)
+					}
+					row = append(row, image)
+				}
+				writeMarkdownRow(&b, row)
+
+				for _, dimensionRow := range getDimensionRows(c, rt, resourceName, containerName) {
+					row := []string{rt, resourceName, dimensionRow.label}
+					for i, cell := range dimensionRow.cells {
+						if dimensionRow.deviating[i] {
+							cell = fmt.Sprintf("**`%s`**", cell)
+						} else if cell != emptyCell {
+							cell = fmt.Sprintf("`%s`", cell)
+						}
+						row = append(row, cell)
+					}
+					writeMarkdownRow(&b, row)
+				}
+			}
+			for _, field := range c.GetFieldNames(rt, resourceName) {
+				row := []string{rt, resourceName, field}
+				deviating := getFieldDeviations(c, rt, resourceName, field)
+				for i, ctx := range c.Contexts {
+					value := getFieldCell(c, rt, resourceName, field, ctx, opts)
+					if deviating[i] {
+						value = fmt.Sprintf("**`%s`**", value)
+					} else if value != emptyCell {
+						value = fmt.Sprintf("`%s`", value)
+					}
+					row = append(row, value)
+				}
+				writeMarkdownRow(&b, row)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	for i, cell := range cells {
+		cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	fmt.Fprintf(b, "| %s |\n", strings.Join(cells, " | "))
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+const presentCell = "✓"
+
+// NamespaceFormats is the list of supported output formats for namespace presence.
+var NamespaceFormats = []string{"table", "json", "yaml"}
+
+type namespaceDocument struct {
+	Name string `json:"name"`
+	// Contexts is keyed by context name and is true if the namespace exists in it.
+	Contexts map[string]bool `json:"contexts"`
+}
+
+// WriteNamespaces writes a namespace x context presence matrix in the given output format.
+// If differencesOnly is set, namespaces present in all contexts are skipped.
+func WriteNamespaces(w io.Writer, format string, contexts []string, presence map[string]map[string]bool, differencesOnly bool) error {
+	var namespaces []string
+	for ns := range presence {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	docs := []namespaceDocument{}
+	for _, ns := range namespaces {
+		if differencesOnly && isPresentInAll(presence[ns]) {
+			continue
+		}
+		docs = append(docs, namespaceDocument{Name: ns, Contexts: presence[ns]})
+	}
+
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(append([]string{"NAMESPACE"}, contexts...), "\t"))
+		for _, doc := range docs {
+			row := []string{doc.Name}
+			for _, ctx := range contexts {
+				if doc.Contexts[ctx] {
+					row = append(row, presentCell)
+				} else {
+					row = append(row, emptyCell)
+				}
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(docs)
+	case "yaml":
+		out, err := yaml.Marshal(docs)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	}
+	return fmt.Errorf("unknown output format '%s'", format)
+}
+
+func isPresentInAll(contexts map[string]bool) bool {
+	for _, present := range contexts {
+		if !present {
+			return false
+		}
+	}
+	return true
+}
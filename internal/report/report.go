@@ -0,0 +1,79 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"kdiff/internal/kube"
+)
+
+const appName = "kdiff"
+
+// Formats is the list of supported output formats.
+var Formats = []string{"table", "json", "yaml", "markdown", "html", "junit", "csv", "tsv"}
+
+// summary holds resource counts of a comparison. A resource can be both drifted & missing.
+type summary struct {
+	InSync  int
+	Drifted int
+	Missing int
+}
+
+func newSummary(c *kube.Comparison) summary {
+	var s summary
+	for _, rt := range c.ResourceTypes {
+		for _, resourceName := range c.GetResourceNames(rt) {
+			drifted, missing := c.HasDifferences(rt, resourceName), c.IsMissing(rt, resourceName)
+			if drifted {
+				s.Drifted++
+			}
+			if missing {
+				s.Missing++
+			}
+			if !drifted && !missing {
+				s.InSync++
+			}
+		}
+	}
+	return s
+}
+
+// Write writes a comparison in the given output format.
+func Write(w io.Writer, format string, c *kube.Comparison, opts Options) error {
+	switch format {
+	case "table":
+		return WriteTable(w, c, opts)
+	case "json":
+		return WriteJSON(w, c, opts)
+	case "yaml":
+		return WriteYAML(w, c, opts)
+	case "markdown":
+		return WriteMarkdown(w, c, opts)
+	case "html":
+		return WriteHTML(w, c, opts)
+	case "junit":
+		return WriteJUnit(w, c, opts)
+	case "csv":
+		return WriteCSV(w, c, opts, ',')
+	case "tsv":
+		return WriteCSV(w, c, opts, '\t')
+	}
+	return fmt.Errorf("unknown output format '%s'", format)
+}
+
+// formatDigest returns an image digest including its algorithm prefix.
+func formatDigest(hash string) string {
+	if hash == "" {
+		return ""
+	}
+	return "sha256:" + hash
+}
+
+// IsFormat returns true if format is a supported output format.
+func IsFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"kdiff/internal/helpers"
+	"kdiff/internal/kube"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	statusInSync     = "ok"
+	statusDrift      = "drift"
+	statusPodsDiffer = "pods-differ"
+	statusRollingOut = "rolling-out"
+	emptyCell        = "-"
+	missingCell      = "<missing>"
+	podsDifferMarker = "(pods differ)"
+)
+
+// Options control which rows & image components are written to a report.
+type Options struct {
+	DifferencesOnly bool
+	ShowImageHash   bool
+	// ShowReadyReplicas adds the ready replica count to the "replicas" field of workloads,
+	// which only exists if the "replicas" dimension is compared.
+	ShowReadyReplicas bool
+}
+
+// showReadyReplicas returns true if ready replica counts are shown for a comparison.
+func showReadyReplicas(c *kube.Comparison, opts Options) bool {
+	return opts.ShowReadyReplicas && slices.Contains(c.GetComparedDimensions(), "replicas")
+}
+
+// WriteTable writes a comparison as a plain text table.
+func WriteTable(w io.Writer, c *kube.Comparison, opts Options) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	header := append([]string{"KIND", "NAME", "CONTAINER/KEY"}, c.Contexts...)
+	header = append(header, "STATUS")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, rt := range c.ResourceTypes {
+		for _, resourceName := range c.GetResourceNames(rt) {
+			if opts.DifferencesOnly && !c.HasDifferences(rt, resourceName) {
+				continue
+			}
+			for _, containerName := range c.GetContainerNames(rt, resourceName) {
+				row := []string{rt, resourceName, c.GetContainerLabel(rt, resourceName, containerName)}
+				for _, ctx := range c.Contexts {
+					row = append(row, getImageCell(c, rt, resourceName, containerName, ctx, opts))
+				}
+				status := statusInSync
+				if c.IsMismatch(rt, resourceName, containerName) {
+					status = statusDrift
+				} else if dimensions := c.GetMismatchingDimensions(rt, resourceName, containerName); len(dimensions) > 0 {
+					status = fmt.Sprintf("%s-%s", strings.Join(dimensions, ","), statusDrift)
+				} else if isAnyRunningMismatch(c, rt, resourceName, containerName) {
+					status = statusPodsDiffer
+				} else if c.HasRolloutInProgress(rt, resourceName) {
+					status = statusRollingOut
+				}
+				row = append(row, status)
+				fmt.Fprintln(tw, strings.Join(row, "\t"))
+
+				for _, dimensionRow := range getDimensionRows(c, rt, resourceName, containerName) {
+					row := append([]string{rt, resourceName, dimensionRow.label}, dimensionRow.cells...)
+					row = append(row, statusDrift)
+					fmt.Fprintln(tw, strings.Join(row, "\t"))
+				}
+			}
+			for _, field := range c.GetFieldNames(rt, resourceName) {
+				row := []string{rt, resourceName, field}
+				for _, ctx := range c.Contexts {
+					row = append(row, getFieldCell(c, rt, resourceName, field, ctx, opts))
+				}
+				status := statusInSync
+				if c.IsFieldMismatch(rt, resourceName, field) {
+					status = statusDrift
+				}
+				row = append(row, status)
+				fmt.Fprintln(tw, strings.Join(row, "\t"))
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// isAnyRunningMismatch returns true if pods of a container don't run the declared image in any context.
+func isAnyRunningMismatch(c *kube.Comparison, rt, resourceName, containerName string) bool {
+	for _, ctx := range c.Contexts {
+		if c.IsRunningMismatch(rt, resourceName, containerName, ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// getImageCell returns the image of a container in a context, or emptyCell if
+// the container doesn't exist there. Images not matching running pods are marked and
+// deployments rolling out list the images of all active ReplicaSets with replica counts.
+func getImageCell(c *kube.Comparison, rt, resourceName, containerName, ctx string, opts Options) string {
+	res := c.GetResource(rt, resourceName, containerName, ctx)
+	if res == nil {
+		return emptyCell
+	}
+	image, err := res.GetImage(containerName, true, true, true, opts.ShowImageHash)
+	if err != nil {
+		return emptyCell
+	}
+	if res.IsRollingOut() {
+		image = strings.Join(res.GetRolloutImageNames(containerName, true, true, true, opts.ShowImageHash), ", ")
+	}
+	if c.IsRunningMismatch(rt, resourceName, containerName, ctx) {
+		return fmt.Sprintf("%s %s", image, podsDifferMarker)
+	}
+	return image
+}
+
+// getFieldCell returns a summary of the value of a field in a context, emptyCell if
+// the resource doesn't exist there or missingCell if the resource lacks the field.
+func getFieldCell(c *kube.Comparison, rt, resourceName, field, ctx string, opts Options) string {
+	if !c.IsPresent(rt, resourceName, ctx) {
+		return emptyCell
+	}
+	value, exists := c.GetFieldValue(rt, resourceName, field, ctx)
+	if !exists {
+		return missingCell
+	}
+	if _, ready, hasReplicas := c.GetReplicas(rt, resourceName, ctx); showReadyReplicas(c, opts) && hasReplicas && field == "replicas" {
+		return fmt.Sprintf("%s (%d ready)", value, ready)
+	}
+	return helpers.SummarizeValue(value, helpers.MaxValueLength)
+}
+
+// dimensionRow is a key of a container dimension (e.g. an environment variable) which
+// differs across contexts, with a cell for each context and whether it deviates.
+type dimensionRow struct {
+	label     string
+	cells     []string
+	deviating []bool
+}
+
+// getDimensionRows returns a row for each key of the compared dimensions of a container
+// which differs across contexts.
+func getDimensionRows(c *kube.Comparison, rt, resourceName, containerName string) []dimensionRow {
+	var rows []dimensionRow
+	for _, dimension := range c.GetMismatchingDimensions(rt, resourceName, containerName) {
+		for _, key := range c.GetDimensionMismatches(rt, resourceName, containerName, dimension) {
+			row := dimensionRow{label: fmt.Sprintf("%s: %s", containerName, key)}
+			var values []string
+			var present []bool
+			for _, ctx := range c.Contexts {
+				cell := emptyCell
+				res := c.GetResource(rt, resourceName, containerName, ctx)
+				if res != nil {
+					cell = missingCell
+					value, exists := res.GetContainerField(containerName, dimension, key)
+					if exists {
+						cell = helpers.SummarizeValue(value, helpers.MaxValueLength)
+					}
+					values = append(values, fmt.Sprintf("%t:%s", exists, value))
+				} else {
+					values = append(values, "")
+				}
+				present = append(present, res != nil)
+				row.cells = append(row.cells, cell)
+			}
+			row.deviating = getDeviatingCells(values, present)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// getFieldDeviations returns for each context whether the value of a field deviates. No
+// value deviates if the field has the same value in all contexts.
+func getFieldDeviations(c *kube.Comparison, rt, resourceName, field string) []bool {
+	if !c.IsFieldMismatch(rt, resourceName, field) {
+		return make([]bool, len(c.Contexts))
+	}
+	var values []string
+	var present []bool
+	for _, ctx := range c.Contexts {
+		value, exists := c.GetFieldValue(rt, resourceName, field, ctx)
+		values = append(values, fmt.Sprintf("%t:%s", exists, value))
+		present = append(present, c.IsPresent(rt, resourceName, ctx))
+	}
+	return getDeviatingCells(values, present)
+}
+
+// getDeviatingCells returns whether each value differs from the value shared by most contexts
+// the resource exists in. If no single value is shared by most contexts, every value deviates.
+func getDeviatingCells(values []string, present []bool) []bool {
+	counts := make(map[string]int)
+	for i, value := range values {
+		if present[i] {
+			counts[value]++
+		}
+	}
+	var majority string
+	var majorityCount int
+	var tie bool
+	for value, count := range counts {
+		if count > majorityCount {
+			majority, majorityCount, tie = value, count, false
+		} else if count == majorityCount {
+			tie = true
+		}
+	}
+
+	deviating := make([]bool, len(values))
+	for i, value := range values {
+		deviating[i] = present[i] && (tie || value != majority)
+	}
+	return deviating
+}
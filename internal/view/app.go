@@ -2,6 +2,7 @@ package view
 
 import (
 	"kdiff/internal/config"
+	"kdiff/internal/helpers"
 	"kdiff/internal/kube"
 
 	"github.com/gdamore/tcell/v2"
@@ -19,17 +20,19 @@ const (
 
 var (
 	app     *tview.Application
+	pages   *tview.Pages
 	kconfig kube.KubeConfig
 )
 
 func App(config config.ConfigFlags) {
 	// Parse kubeconfig and Initialize kubernetes client for each context.
 	kconfig.ParseConfig(config.KubeConfig)
-	kconfig.InitializeClients()
+	helpers.HandleError(kconfig.InitializeClients())
 
 	// Create new tview app & run it.
 	app = tview.NewApplication()
 	buildAppUI()
+	ui.preselect(*config.KubeConfig.Context, *config.KubeConfig.Namespace)
 	if err := app.Run(); err != nil {
 		panic(err)
 	}
@@ -43,7 +46,7 @@ func buildAppUI() {
 
 	// Create the layout.
 	grid := tview.NewGrid().
-		SetRows(6, 0, 1).
+		SetRows(headerHeight, 0, 1).
 		SetBorders(false).
 		// Header Grid
 		AddItem(tview.NewGrid().
@@ -80,13 +83,18 @@ func buildAppUI() {
 	ui.updateUI(false, false, false, false, false, true)
 
 	// Setup the pages
-	pages := tview.NewPages().
+	pages = tview.NewPages().
 		AddPage(" kdiff ", grid, true, true)
 	app.SetRoot(pages, true).
 		SetFocus(ui.contextList).
 
 		// Setup navigation
 		SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			// Keys are handled by the drill-down modal while it's open.
+			if pages.HasPage(drillDownPage) {
+				return event
+			}
+
 			navOrder := []tview.Primitive{
 				ui.contextList,
 				ui.namespaceList,
@@ -136,6 +144,30 @@ func buildAppUI() {
 			case 'd':
 				ui.options.showDifferencesOnly = !ui.options.showDifferencesOnly
 				ui.updateUI(true, false, false, false, true, false)
+			case 'p':
+				ui.options.checkRunningPods = !ui.options.checkRunningPods
+				ui.updateUI(true, false, false, false, true, false)
+			case 'o':
+				ui.options.showRollouts = !ui.options.showRollouts
+				ui.updateUI(true, false, false, false, true, false)
+			case 'e':
+				ui.options.compareEnv = !ui.options.compareEnv
+				ui.updateUI(true, false, false, false, true, false)
+			case 'u':
+				ui.options.compareResources = !ui.options.compareResources
+				ui.updateUI(true, false, false, false, true, false)
+			case 'm':
+				ui.options.compareMetadata = !ui.options.compareMetadata
+				ui.updateUI(true, false, false, false, true, false)
+			case 'b':
+				ui.options.compareProbes = !ui.options.compareProbes
+				ui.updateUI(true, false, false, false, true, false)
+			case 'c':
+				ui.options.compareReplicas = !ui.options.compareReplicas
+				ui.updateUI(true, false, false, false, true, false)
+			case 'y':
+				ui.options.showReadyReplicas = !ui.options.showReadyReplicas
+				ui.updateUI(true, false, false, false, true, false)
 			}
 
 			// Enable display area table selection only if its in focus.
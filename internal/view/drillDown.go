@@ -0,0 +1,83 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const drillDownPage = "drillDown"
+
+// displayRow identifies the resource and the container or field displayed in a row of
+// the display area.
+type displayRow struct {
+	rt            string
+	resourceName  string
+	containerName string
+	field         string
+}
+
+// showDrillDown opens a modal with the differences of the container or field displayed in
+// a row of the display area: a unified diff for fields and a listing of the keys of every
+// mismatching dimension for containers. Rows without differences are ignored.
+func (u *uiElements) showDrillDown(row int) {
+	selected, exists := u.displayRows[row]
+	if !exists {
+		return
+	}
+
+	var title, text string
+	if selected.field != "" && u.comparison.IsFieldMismatch(selected.rt, selected.resourceName, selected.field) {
+		title = fmt.Sprintf(" %s/%s: %s ", selected.rt, selected.resourceName, selected.field)
+		text = colorizeDiff(u.comparison.GetFieldDiff(selected.rt, selected.resourceName, selected.field))
+	} else if dimensions := u.comparison.GetMismatchingDimensions(selected.rt, selected.resourceName, selected.containerName); selected.containerName != "" && len(dimensions) > 0 {
+		title = fmt.Sprintf(" %s/%s: %s ", selected.rt, selected.resourceName, selected.containerName)
+		for _, dimension := range dimensions {
+			text += tview.Escape(u.comparison.GetDimensionDiff(selected.rt, selected.resourceName, selected.containerName, dimension))
+		}
+	} else {
+		return
+	}
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(text)
+	textView.SetBorder(true).
+		SetTitle(tview.Escape(title))
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter || event.Rune() == 'q' {
+			pages.RemovePage(drillDownPage)
+			app.SetFocus(u.displayArea)
+			return nil
+		}
+		return event
+	})
+
+	// Center the modal with a margin around it.
+	modal := tview.NewGrid().
+		SetColumns(0, -8, 0).
+		SetRows(0, -8, 0).
+		AddItem(textView, 1, 1, 1, 1, 0, 0, true)
+	pages.AddPage(drillDownPage, modal, true, true)
+	app.SetFocus(textView)
+}
+
+// colorizeDiff returns a unified diff with added lines in green & removed lines in red.
+func colorizeDiff(diff string) string {
+	lines := strings.Split(tview.Escape(diff), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = fmt.Sprintf("[::b]%s[::-]", line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = fmt.Sprintf("[green]%s[-]", line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = fmt.Sprintf("[red]%s[-]", line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = fmt.Sprintf("[blue]%s[-]", line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -9,6 +9,7 @@ import (
 
 type multiSelectListItem struct {
 	Text       string
+	Marker     string
 	Selected   func()
 	IsDisabled bool
 }
@@ -46,6 +47,13 @@ func (m *multiSelectList) addItem(text string, isDisabled bool, selected func())
 	return m
 }
 
+// setItemMarker sets a marker which is displayed after the text of the item at
+// the given index. The marker may contain color tags.
+func (m *multiSelectList) setItemMarker(index int, marker string) *multiSelectList {
+	m.getItem(index).Marker = marker
+	return m
+}
+
 func (m *multiSelectList) Draw(screen tcell.Screen) {
 	m.Box.DrawForSubclass(screen, m)
 	x, y, width, height := m.GetInnerRect()
@@ -60,6 +68,9 @@ func (m *multiSelectList) Draw(screen tcell.Screen) {
 		}
 
 		line := fmt.Sprintf(" [green::b]%s[-::-] %s", selectedIcon, item.Text)
+		if item.Marker != "" {
+			line = fmt.Sprintf("%s %s", line, item.Marker)
+		}
 
 		var (
 			color tcell.Color
@@ -232,6 +243,13 @@ func (m *multiSelectList) getItemTextMultiple(indices []int) []string {
 	return list
 }
 
+// SelectItem selects the item at the given index unless it is disabled.
+func (m *multiSelectList) SelectItem(index int) {
+	if !m.IsItemDisabled(index) && indexOf(m.selectedItems, index) < 0 {
+		m.selectedItems = append(m.selectedItems, index)
+	}
+}
+
 func (m *multiSelectList) SelectAllItems() {
 	m.selectedItems = nil
 	for index, item := range m.items {
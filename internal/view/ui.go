@@ -5,21 +5,23 @@ import (
 	"kdiff/internal/helpers"
 	"kdiff/internal/kube"
 	"regexp"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
-	"k8s.io/utils/strings/slices"
 )
 
-var unreachableError = make(map[string]error)
+const (
+	// headerHeight is the number of rows of the app header.
+	headerHeight = 6
+)
 
-type getKubeResourceResult struct {
-	rt        string
-	ctx       string
-	resources []*kube.AppsV1Resource
-}
+var (
+	unreachableError  = make(map[string]error)
+	namespacePresence = make(map[string]map[string]bool)
+)
 
 type uiOptions struct {
 	showImageRegistryName bool
@@ -28,6 +30,15 @@ type uiOptions struct {
 	showImageHash         bool
 
 	showDifferencesOnly bool
+	checkRunningPods    bool
+	showRollouts        bool
+
+	compareEnv        bool
+	compareMetadata   bool
+	compareProbes     bool
+	compareReplicas   bool
+	compareResources  bool
+	showReadyReplicas bool
 }
 
 type uiElements struct {
@@ -52,6 +63,10 @@ type uiElements struct {
 	footerRight  *tview.TextView
 
 	focusedElement *tview.Primitive
+
+	// Comparison shown in the display area & the resource displayed in each row.
+	comparison  *kube.Comparison
+	displayRows map[int]displayRow
 }
 
 // Initializes all UI elements with initial content.
@@ -88,7 +103,10 @@ func (u *uiElements) initialize() {
 	u.namespaceList = createMultiSelectList("namespaces")
 	u.resourceTypeList = createMultiSelectList("resource types")
 	u.displayArea = tview.NewTable().
-		SetFixed(1, 0)
+		SetFixed(1, 0).
+		SetSelectedFunc(func(row, column int) {
+			u.showDrillDown(row)
+		})
 	u.displayArea.SetBorder(true)
 
 	// Initialize footer elements.
@@ -121,6 +139,12 @@ func (u *uiElements) initialize() {
 	})
 	u.namespaceList.SetSelectedFunc(func(index int, text string) {
 		u.updateUI(false, false, false, false, true, false)
+	}).SetHighlightedFunc(func(index int, text string) {
+		if missing := getMissingContexts(namespacePresence[text]); len(missing) > 0 {
+			u.footerLeft.SetText(fmt.Sprintf("[yellow]Namespace '%s' is missing in: %s[-]", text, strings.Join(missing, ", ")))
+		} else {
+			u.footerLeft.SetText("")
+		}
 	})
 	u.resourceTypeList.SetSelectedFunc(func(index int, text string) {
 		u.updateUI(false, false, false, false, true, false)
@@ -158,7 +182,7 @@ func (u *uiElements) updateFooterLeft() {
 
 // updateContextList updates the context tview list.
 func (u *uiElements) updateContextList() {
-	for _, ctx := range kconfig.GetContextInfo() {
+	for _, ctx := range kconfig.GetContextInfo(kube.DefaultContextTimeout) {
 		if ctx.Reachable {
 			u.contextList.addItem(fmt.Sprintf("%s [%s]", ctx.Name, ctx.ServerVersion), false, nil)
 		} else {
@@ -168,6 +192,32 @@ func (u *uiElements) updateContextList() {
 	}
 }
 
+// preselect selects the given context & namespace (e.g. set through kubectl flags) if they exist.
+func (u *uiElements) preselect(ctx, namespace string) {
+	if ctx == "" {
+		return
+	}
+	for index := 0; index < u.contextList.GetItemCount(); index++ {
+		cleanName, err := cleanContextName(u.contextList.GetItemText(index))
+		helpers.HandleError(err)
+		if cleanName == ctx {
+			u.contextList.SelectItem(index)
+			u.updateUI(false, false, true, false, false, false)
+			break
+		}
+	}
+
+	if namespace == "" {
+		return
+	}
+	for index := 0; index < u.namespaceList.GetItemCount(); index++ {
+		if u.namespaceList.GetItemText(index) == namespace {
+			u.namespaceList.SelectItem(index)
+			break
+		}
+	}
+}
+
 // getActiveContexts returns a list of cleaned context names from activeContexts.
 func (u *uiElements) getActiveContexts() []string {
 	activeContexts := u.contextList.getItemTextMultiple(u.contextList.GetSelectedItems())
@@ -185,37 +235,44 @@ func (u *uiElements) getActiveContexts() []string {
 func (u *uiElements) updateNamespaceList() {
 	activeContexts := u.getActiveContexts()
 
+	namespacePresence = kube.GetNamespacePresence(activeContexts)
+
 	u.namespaceList.Clear()
-	for _, ns := range kube.GetNamespacesForContextList(activeContexts) {
+	for index, ns := range helpers.GetSortedMapKeysBool(getNamespaceSet(namespacePresence)) {
 		u.namespaceList.addItem(ns, false, nil)
+
+		// Mark namespaces which don't exist in all active contexts.
+		if missing := getMissingContexts(namespacePresence[ns]); len(missing) > 0 {
+			presentCount := len(activeContexts) - len(missing)
+			u.namespaceList.setItemMarker(index, fmt.Sprintf("[yellow](%d/%d)[-]", presentCount, len(activeContexts)))
+		}
 	}
 }
 
-// updateResourceTypeList updates the resourceType tview list.
-func (u *uiElements) updateResourceTypeList() {
-	resourceTypes := []string{
-		"DaemonSet", "Deployment", "StatefulSet",
+// getNamespaceSet returns the set of namespaces in a namespace presence map.
+func getNamespaceSet(presence map[string]map[string]bool) map[string]bool {
+	namespaces := make(map[string]bool)
+	for ns := range presence {
+		namespaces[ns] = true
 	}
+	return namespaces
+}
 
-	for _, rt := range resourceTypes {
-		u.resourceTypeList.addItem(rt, false, nil)
+// getMissingContexts returns a sorted list of contexts a namespace is missing in.
+func getMissingContexts(contexts map[string]bool) []string {
+	var missing []string
+	for _, ctx := range helpers.GetSortedMapKeysBool(contexts) {
+		if !contexts[ctx] {
+			missing = append(missing, ctx)
+		}
 	}
+	return missing
 }
 
-func getKubeResources(rt, ctx, ns string, out chan<- getKubeResourceResult) {
-	var resources []*kube.AppsV1Resource
-
-	if rt == "Deployment" {
-		resources = kube.GetDeployments(ctx, ns)
-	} else if rt == "StatefulSet" {
-		resources = kube.GetStatefulSets(ctx, ns)
-	} else if rt == "DaemonSet" {
-		resources = kube.GetDaemonSets(ctx, ns)
-	}
-	out <- getKubeResourceResult{
-		rt:        rt,
-		ctx:       ctx,
-		resources: resources,
+// updateResourceTypeList updates the resourceType tview list.
+func (u *uiElements) updateResourceTypeList() {
+	for _, rt := range kube.ResourceTypes {
+		u.resourceTypeList.addItem(rt, false, nil)
 	}
 }
 
@@ -233,62 +290,20 @@ func (u *uiElements) updateDisplayArea() {
 	// Clear table.
 	u.displayArea.Clear()
 
-	var (
-		allResources  = make(map[string]map[string]map[string]map[string]*kube.AppsV1Resource)
-		contextIndex  = make(map[string]int)
-		mistmatches   = make(map[string][]string)
-		chanResources = make(chan getKubeResourceResult)
-	)
-
-	// Concurrently get resources.
-	for i, ctx := range activeContexts {
-		contextIndex[ctx] = i
-		for _, rt := range activeResourceTypes {
-			for _, ns := range activeNamespaces {
-				go getKubeResources(rt, ctx, ns, chanResources)
-			}
-		}
+	comparison, err := kube.CompareResources(activeContexts, activeResourceTypes, activeNamespaces)
+	helpers.HandleError(err)
+	u.comparison = comparison
+	u.displayRows = make(map[int]displayRow)
+	if u.options.checkRunningPods {
+		helpers.HandleError(comparison.CheckRunningImages())
 	}
-
-	// Collect all results.
-	for i := 0; i < (len(activeContexts) * len(activeResourceTypes) * len(activeNamespaces)); i++ {
-		result := <-chanResources
-
-		for _, res := range result.resources {
-			resourceName := res.GetName()
-			if _, exists := allResources[result.rt]; !exists {
-				allResources[result.rt] = make(map[string]map[string]map[string]*kube.AppsV1Resource)
-			}
-			if _, exists := allResources[result.rt][resourceName]; !exists {
-				allResources[result.rt][resourceName] = make(map[string]map[string]*kube.AppsV1Resource)
-			}
-			containers := res.GetContainers()
-
-			for _, containerName := range containers {
-				if _, exists := allResources[result.rt][resourceName][containerName]; !exists {
-					allResources[result.rt][resourceName][containerName] = make(map[string]*kube.AppsV1Resource)
-				}
-				allResources[result.rt][resourceName][containerName][result.ctx] = res
-			}
-		}
+	if u.options.showRollouts {
+		helpers.HandleError(comparison.CheckRollouts())
 	}
-
-	// Identify mismatching images
-	for rt, resourceMap := range allResources {
-		for resourceName, containerMap := range resourceMap {
-			for containerName, contextMap := range containerMap {
-				var allImages []string
-				for _, res := range contextMap {
-					fullImageName, err := res.GetImage(containerName, true, true, true, true)
-					helpers.HandleError(err)
-					allImages = append(allImages, fullImageName)
-				}
-				if len(helpers.GetUniqueStrings(allImages)) != 1 {
-					key := fmt.Sprintf("%s-%s", rt, resourceName)
-					mistmatches[key] = append(mistmatches[key], containerName)
-				}
-			}
-		}
+	comparison.CompareDimensions(u.getComparedDimensions())
+	contextIndex := make(map[string]int)
+	for i, ctx := range activeContexts {
+		contextIndex[ctx] = i
 	}
 
 	// Fill table.
@@ -303,15 +318,15 @@ func (u *uiElements) updateDisplayArea() {
 		SetTextColor(tcell.GetColor("#f5bd07")))
 	for _, rt := range activeResourceTypes {
 		// Set header only if there are any resources to be displayed.
-		if _, exists := allResources[rt]; exists {
+		if comparison.HasResources(rt) {
 			u.displayArea.SetCell(row, 0, tview.NewTableCell(rt).
 				SetAttributes(tcell.AttrBold).
 				SetTextColor(tcell.GetColor("#f5bd07")))
 		}
-		for resourceName, containerMap := range allResources[rt] {
-			mistmatchesKey := fmt.Sprintf("%s-%s", rt, resourceName)
+		for _, resourceName := range comparison.GetResourceNames(rt) {
+			hasMismatch := comparison.HasMismatch(rt, resourceName)
 			if u.options.showDifferencesOnly {
-				if _, exists := mistmatches[mistmatchesKey]; exists {
+				if comparison.HasDifferences(rt, resourceName) {
 					setTableCell(u, row, 1, resourceName)
 				} else {
 					continue
@@ -320,18 +335,23 @@ func (u *uiElements) updateDisplayArea() {
 				setTableCell(u, row, 1, resourceName)
 			}
 
-			for containerName := range containerMap {
+			for _, containerName := range comparison.GetContainerNames(rt, resourceName) {
 				// Set empty cell at column 1 if it doesn't already contain some text.
 				if len(u.displayArea.GetCell(row, 1).Text) < 1 {
 					setTableCell(u, row, 1, "")
 				}
+				// Label init containers since container names aren't displayed.
+				if comparison.IsInitContainer(rt, resourceName, containerName) {
+					nameCell := u.displayArea.GetCell(row, 1).Text
+					setTableCell(u, row, 1, fmt.Sprintf("%s [gray](init: %s)[-]", nameCell, containerName))
+				}
 
 				for _, ctx := range activeContexts {
 					column = contextIndex[ctx] + 2
 
-					if _, exists := containerMap[containerName][ctx]; exists {
+					if res := comparison.GetResource(rt, resourceName, containerName, ctx); res != nil {
 						// Lookup errors should be ignored here just in case user disables all 4 options.
-						imageDisplayName, _ := containerMap[containerName][ctx].GetImage(
+						imageDisplayName, _ := res.GetImage(
 							containerName,
 							u.options.showImageRegistryName,
 							u.options.showImageName,
@@ -342,11 +362,32 @@ func (u *uiElements) updateDisplayArea() {
 							SetAttributes(tcell.AttrBold).
 							SetExpansion(6).
 							SetTextColor(tcell.GetColor("#f5bd07")))
-						if _, exists := mistmatches[mistmatchesKey]; exists {
-							if slices.Contains(mistmatches[mistmatchesKey], containerName) {
-								setTableCellWithBackgroundColor(u, row, column, imageDisplayName, tcell.ColorRed)
-							}
-						} else if !u.options.showDifferencesOnly {
+						// Deployments rolling out show all active images with their replica counts.
+						if res.IsRollingOut() {
+							imageDisplayName = strings.Join(res.GetRolloutImageNames(
+								containerName,
+								u.options.showImageRegistryName,
+								u.options.showImageName,
+								u.options.showImageTag,
+								u.options.showImageHash,
+							), ", ")
+						}
+						if comparison.IsRunningMismatch(rt, resourceName, containerName, ctx) {
+							imageDisplayName = fmt.Sprintf("%s \u26a0", imageDisplayName)
+						}
+						mismatchingDimensions := comparison.GetMismatchingDimensions(rt, resourceName, containerName)
+						if len(mismatchingDimensions) > 0 {
+							imageDisplayName = fmt.Sprintf("%s (%s differs)", imageDisplayName, strings.Join(mismatchingDimensions, ", "))
+						}
+						if hasMismatch && comparison.IsMismatch(rt, resourceName, containerName) {
+							setTableCellWithBackgroundColor(u, row, column, imageDisplayName, tcell.ColorRed)
+						} else if len(mismatchingDimensions) > 0 {
+							setTableCellWithBackgroundColor(u, row, column, imageDisplayName, tcell.ColorOrange)
+						} else if comparison.IsRunningMismatch(rt, resourceName, containerName, ctx) {
+							setTableCellWithBackgroundColor(u, row, column, imageDisplayName, tcell.ColorYellow)
+						} else if res.IsRollingOut() {
+							setTableCellWithBackgroundColor(u, row, column, imageDisplayName, tcell.ColorFuchsia)
+						} else if !hasMismatch && !u.options.showDifferencesOnly {
 							setTableCell(u, row, column, imageDisplayName)
 						}
 					} else {
@@ -354,6 +395,44 @@ func (u *uiElements) updateDisplayArea() {
 						setTableCell(u, row, column, "")
 					}
 				}
+				u.displayRows[row] = displayRow{rt: rt, resourceName: resourceName, containerName: containerName}
+				row++
+			}
+
+			for _, field := range comparison.GetFieldNames(rt, resourceName) {
+				if len(u.displayArea.GetCell(row, 1).Text) < 1 {
+					setTableCell(u, row, 1, "")
+				}
+				// Label fields since they are displayed below the resource name.
+				nameCell := u.displayArea.GetCell(row, 1).Text
+				setTableCell(u, row, 1, fmt.Sprintf("%s [gray](%s)[-]", nameCell, tview.Escape(field)))
+
+				fieldMismatch := comparison.IsFieldMismatch(rt, resourceName, field)
+				for _, ctx := range activeContexts {
+					column = contextIndex[ctx] + 2
+					u.displayArea.SetCell(0, column, tview.NewTableCell(ctx).
+						SetAttributes(tcell.AttrBold).
+						SetExpansion(6).
+						SetTextColor(tcell.GetColor("#f5bd07")))
+
+					value, exists := comparison.GetFieldValue(rt, resourceName, field, ctx)
+					displayValue := tview.Escape(helpers.SummarizeValue(value, helpers.MaxValueLength))
+					if _, ready, hasReplicas := comparison.GetReplicas(rt, resourceName, ctx); u.options.showReadyReplicas && hasReplicas && field == "replicas" {
+						displayValue = fmt.Sprintf("%s [gray](%d ready)[-]", displayValue, ready)
+					}
+					if !comparison.IsPresent(rt, resourceName, ctx) {
+						setTableCell(u, row, column, "")
+					} else if fieldMismatch && !exists {
+						setTableCellWithBackgroundColor(u, row, column, "<missing>", tcell.ColorRed)
+					} else if fieldMismatch {
+						setTableCellWithBackgroundColor(u, row, column, displayValue, tcell.ColorRed)
+					} else if !hasMismatch && !u.options.showDifferencesOnly {
+						setTableCell(u, row, column, displayValue)
+					} else {
+						setTableCell(u, row, column, "")
+					}
+				}
+				u.displayRows[row] = displayRow{rt: rt, resourceName: resourceName, field: field}
 				row++
 			}
 		}
@@ -393,6 +472,7 @@ func getMenuText(mode string) string {
 		"<Shift + TAB>": "Cycle backward",
 		"<Space>":       "Select item",
 		"<a>":           "Select all (toggle)",
+		"<Enter>":       "Show differences",
 	}
 	focusKeys := map[string]string{
 		"<1>": "Contexts",
@@ -415,21 +495,59 @@ func getMenuText(mode string) string {
 
 func (u *uiElements) updateToggles() {
 	options := map[string]bool{
-		"<r>  Show Image Registry Name": u.options.showImageRegistryName,
-		"<n>  Show Image Name":          u.options.showImageName,
-		"<t>  Show Image Tag":           u.options.showImageTag,
-		"<h>  Show Image Hash":          u.options.showImageHash,
-		"<d>  Show Differences Only":    u.options.showDifferencesOnly,
+		"<r>  Show Image Registry Name":   u.options.showImageRegistryName,
+		"<n>  Show Image Name":            u.options.showImageName,
+		"<t>  Show Image Tag":             u.options.showImageTag,
+		"<h>  Show Image Hash":            u.options.showImageHash,
+		"<d>  Show Differences Only":      u.options.showDifferencesOnly,
+		"<p>  Check Running Pods":         u.options.checkRunningPods,
+		"<o>  Show Rollouts":              u.options.showRollouts,
+		"<e>  Compare Env":                u.options.compareEnv,
+		"<u>  Compare Requests/Limits":    u.options.compareResources,
+		"<c>  Compare Replicas":           u.options.compareReplicas,
+		"<m>  Compare Labels/Annotations": u.options.compareMetadata,
+		"<b>  Compare Probes":             u.options.compareProbes,
+		"<y>  Show Ready Replicas":        u.options.showReadyReplicas,
 	}
-	var str string
-	for _, k := range helpers.GetSortedMapKeysBool(options) {
+
+	// Toggles are laid out in columns of headerHeight rows.
+	keys := helpers.GetSortedMapKeysBool(options)
+	width := 0
+	for _, k := range keys {
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+	lines := make([]string, headerHeight)
+	for i, k := range keys {
 		color := "gray"
 		if options[k] {
 			color = "green"
 		}
-		str = str + fmt.Sprintf("[%s]%s\n", color, k)
+		lines[i%headerHeight] += fmt.Sprintf("[%s]%-*s", color, width+2, k)
+	}
+	u.headerLeft.SetText(strings.Join(lines, "\n"))
+}
+
+// getComparedDimensions returns the container & workload dimensions toggled for comparison.
+func (u *uiElements) getComparedDimensions() []string {
+	var dimensions []string
+	if u.options.compareEnv {
+		dimensions = append(dimensions, "env")
+	}
+	if u.options.compareMetadata {
+		dimensions = append(dimensions, "metadata")
+	}
+	if u.options.compareProbes {
+		dimensions = append(dimensions, "probes")
+	}
+	if u.options.compareReplicas {
+		dimensions = append(dimensions, "replicas")
+	}
+	if u.options.compareResources {
+		dimensions = append(dimensions, "resources")
 	}
-	u.headerLeft.SetText(str)
+	return dimensions
 }
 
 func cleanContextName(ctx string) (string, error) {